@@ -318,6 +318,7 @@ func getAllCommands() []Group {
 				{Command: "pocket setup list", Desc: "List services needing setup", Flags: "-a all"},
 				{Command: "pocket setup show", Desc: "Show setup instructions", Args: "[service]"},
 				{Command: "pocket setup set", Desc: "Set credential for service", Args: "[service] [key] [value]"},
+				{Command: "pocket doctor", Desc: "Check module prerequisites (tools, permissions, config)"},
 			},
 		},
 		{
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/unstablemind/pocket/internal/common/config"
+	"github.com/unstablemind/pocket/internal/system/contacts"
+	"github.com/unstablemind/pocket/pkg/output"
+)
+
+const statusNotReady = "not_ready"
+
+// doctorTimeout bounds each probe so a hung permission prompt can't stall the report
+const doctorTimeout = 15 * time.Second
+
+// CheckResult is the outcome of a single prerequisite check
+type CheckResult struct {
+	Name    string `json:"name"`
+	OK      bool   `json:"ok"`
+	Message string `json:"message,omitempty"`
+}
+
+// ModuleReport summarizes whether a module's prerequisites are met
+type ModuleReport struct {
+	Module string        `json:"module"`
+	Status string        `json:"status"` // "ready", "not_ready", "unsupported"
+	Checks []CheckResult `json:"checks"`
+	Hint   string        `json:"hint,omitempty"`
+}
+
+func NewDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check environment prerequisites per module",
+		Long: `Verify that each module's prerequisites are in place: required system tools,
+macOS Contacts permission, and config keys for Follow Up Boss and DotLoop.
+Reports ready/not_ready per module with a hint for fixing anything missing.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reports := []ModuleReport{
+				checkContacts(),
+				checkWiFi(),
+				checkServiceKeys("followupboss"),
+				checkServiceKeys("dotloop"),
+			}
+
+			ready := 0
+			for _, r := range reports {
+				if r.Status == statusReady {
+					ready++
+				}
+			}
+
+			return output.Print(map[string]any{
+				"config_path": config.Path(),
+				"ready":       ready,
+				"total":       len(reports),
+				"modules":     reports,
+			})
+		},
+	}
+
+	return cmd
+}
+
+// finalize sets the report status from its checks
+func (r ModuleReport) finalize() ModuleReport {
+	r.Status = statusReady
+	for _, c := range r.Checks {
+		if !c.OK {
+			r.Status = statusNotReady
+			return r
+		}
+	}
+	r.Hint = ""
+	return r
+}
+
+// lookPathCheck reports whether a binary is on PATH
+func lookPathCheck(name string) CheckResult {
+	if _, err := exec.LookPath(name); err != nil {
+		return CheckResult{Name: name, OK: false, Message: name + " not found in PATH"}
+	}
+	return CheckResult{Name: name, OK: true}
+}
+
+func checkContacts() ModuleReport {
+	r := ModuleReport{Module: "contacts", Checks: []CheckResult{}}
+	if runtime.GOOS != "darwin" {
+		r.Status = "unsupported"
+		r.Hint = "Contacts is only available on macOS"
+		return r
+	}
+
+	osa := lookPathCheck("osascript")
+	r.Checks = append(r.Checks, osa)
+	if !osa.OK {
+		return r.finalize()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "osascript", "-l", "JavaScript", "-e",
+		"Application('Contacts').people.length").CombinedOutput()
+	perm := CheckResult{Name: "contacts_permission", OK: err == nil}
+	if err != nil {
+		perm.Message = strings.TrimSpace(string(out))
+		if ctx.Err() == context.DeadlineExceeded {
+			perm.Message = "timed out waiting for Contacts; a permission prompt may be open"
+		}
+		if contacts.IsPermissionDenied(perm.Message) {
+			r.Hint = "Grant access in System Settings > Privacy & Security > Automation (and Contacts) for your terminal app"
+		}
+	}
+	r.Checks = append(r.Checks, perm)
+
+	return r.finalize()
+}
+
+func checkWiFi() ModuleReport {
+	r := ModuleReport{Module: "wifi", Checks: []CheckResult{}}
+
+	switch runtime.GOOS {
+	case "darwin":
+		r.Checks = append(r.Checks, lookPathCheck("system_profiler"))
+	case "linux":
+		r.Checks = append(r.Checks, lookPathCheck("nmcli"))
+		r.Hint = "Install NetworkManager to provide nmcli"
+	default:
+		r.Status = "unsupported"
+		r.Hint = "WiFi commands support macOS and Linux"
+		return r
+	}
+
+	return r.finalize()
+}
+
+// checkServiceKeys verifies the required config keys of a setup service are set
+func checkServiceKeys(service string) ModuleReport {
+	r := ModuleReport{Module: service, Checks: []CheckResult{}}
+	svc, ok := services[service]
+	if !ok {
+		r.Status = "unsupported"
+		return r
+	}
+
+	for _, k := range svc.Keys {
+		if !k.Required {
+			continue
+		}
+		val, _ := config.Get(k.Key)
+		c := CheckResult{Name: k.Key, OK: val != ""}
+		if !c.OK {
+			c.Message = "missing config key"
+		}
+		r.Checks = append(r.Checks, c)
+	}
+	r.Hint = "Run: pocket setup show " + service
+
+	return r.finalize()
+}
@@ -0,0 +1,23 @@
+package commands
+
+import "testing"
+
+func TestCheckServiceKeys(t *testing.T) {
+	writeTestConfig(t, map[string]string{"dotloop_token": "tok"})
+	defer clearTestConfig(t)
+
+	if r := checkServiceKeys("dotloop"); r.Status != statusReady {
+		t.Errorf("dotloop status = %q, want %q: %+v", r.Status, statusReady, r.Checks)
+	}
+
+	r := checkServiceKeys("followupboss")
+	if r.Status != statusNotReady {
+		t.Errorf("followupboss status = %q, want %q", r.Status, statusNotReady)
+	}
+	if len(r.Checks) != 3 {
+		t.Errorf("expected 3 required-key checks, got %d", len(r.Checks))
+	}
+	if r.Hint == "" {
+		t.Error("expected a setup hint for a not-ready module")
+	}
+}
@@ -4,15 +4,21 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/unstablemind/pocket/internal/cli/commands"
+	"github.com/unstablemind/pocket/internal/common/config"
 	"github.com/unstablemind/pocket/pkg/output"
 )
 
 var (
 	outputFormat string
 	verbose      bool
+	configFile   string
 )
 
 func NewRootCmd() *cobra.Command {
+	// Run the root PersistentPreRun even for command groups that define their
+	// own (e.g. platform checks), so global flags always take effect.
+	cobra.EnableTraverseRunHooks = true
+
 	root := &cobra.Command{
 		Use:   "pocket",
 		Short: "Universal CLI for LLM agents",
@@ -20,19 +26,24 @@ func NewRootCmd() *cobra.Command {
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			output.SetFormat(outputFormat)
 			output.SetVerbose(verbose)
+			if configFile != "" {
+				config.SetPath(configFile)
+			}
 		},
 		SilenceUsage:  true,
 		SilenceErrors: true,
 	}
 
 	// Global flags
-	root.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "Output format: json, text, table")
+	root.PersistentFlags().StringVarP(&outputFormat, "output", "o", "json", "Output format: json, text, table (contacts get also accepts vcard)")
 	root.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	root.PersistentFlags().StringVar(&configFile, "config", "", "Config file path (overrides POCKET_CONFIG)")
 
 	// Register command groups
 	root.AddCommand(commands.NewCommandsCmd())
 	root.AddCommand(commands.NewIntegrationsCmd())
 	root.AddCommand(commands.NewSetupCmd())
+	root.AddCommand(commands.NewDoctorCmd())
 	root.AddCommand(commands.NewSocialCmd())
 	root.AddCommand(commands.NewCommsCmd())
 	root.AddCommand(commands.NewDevCmd())
@@ -151,6 +151,13 @@ func Path() string {
 	return configPath
 }
 
+// SetPath overrides the config file location. It takes precedence over the
+// POCKET_CONFIG environment variable and must be called before the first Load.
+func SetPath(path string) {
+	configOnce.Do(func() {})
+	configPath = path
+}
+
 // Load reads the config file
 func Load() (*Config, error) {
 	path := Path()
@@ -43,6 +43,24 @@ func TestPathUsesEnvVar(t *testing.T) {
 	}
 }
 
+func TestSetPathOverridesEnvVar(t *testing.T) {
+	tmpDir := t.TempDir()
+	envPath := filepath.Join(tmpDir, "env.json")
+	flagPath := filepath.Join(tmpDir, "flag.json")
+	resetConfig(envPath)
+	defer func() {
+		os.Unsetenv("POCKET_CONFIG")
+		configOnce = sync.Once{}
+		configPath = ""
+	}()
+
+	SetPath(flagPath)
+
+	if result := Path(); result != flagPath {
+		t.Errorf("expected %s, got %s", flagPath, result)
+	}
+}
+
 func TestLoadReturnsEmptyConfigWhenNoFile(t *testing.T) {
 	setupTempConfig(t)
 
@@ -4,9 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -17,18 +25,40 @@ import (
 
 const apiBaseURL = "https://api.dotloop.com/public/v2"
 
+// uploadTimeout bounds document uploads, which may be much larger than
+// regular JSON API calls.
+const uploadTimeout = 5 * time.Minute
+
+// downloadTimeout bounds a single document download
+const downloadTimeout = 5 * time.Minute
+
 func NewCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "dotloop",
 		Short: "DotLoop transaction management commands",
-		Long:  "DotLoop integration for loops (transactions), profiles, tasks, and documents.",
+		Long: `DotLoop integration for loops (transactions), profiles, tasks, and documents.
+
+For accounts in several companies, the list commands (loops, profiles, tasks)
+are scoped to dotloop_company_id, or to --company-id when given. Commands that
+take a loop ID act on that loop directly and need no scoping.`,
 	}
 
+	cmd.PersistentFlags().StringVar(&companyIDOverride, "company-id", "", "Scope list commands to this company (overrides dotloop_company_id)")
+
 	cmd.AddCommand(newLoopsCmd())
 	cmd.AddCommand(newLoopCmd())
+	cmd.AddCommand(newSummaryCmd())
 	cmd.AddCommand(newProfilesCmd())
+	cmd.AddCommand(newCreateProfileCmd())
+	cmd.AddCommand(newCreateLoopCmd())
 	cmd.AddCommand(newTasksCmd())
+	cmd.AddCommand(newCompleteTaskCmd())
 	cmd.AddCommand(newDocumentsCmd())
+	cmd.AddCommand(newUploadCmd())
+	cmd.AddCommand(newDownloadCmd())
+	cmd.AddCommand(newFinancialsCmd())
+	cmd.AddCommand(newAddParticipantCmd())
+	cmd.AddCommand(newRemoveParticipantCmd())
 
 	return cmd
 }
@@ -39,21 +69,40 @@ type dotloopClient struct {
 	httpClient *http.Client
 }
 
+// companyIDOverride is set by the --company-id persistent flag
+var companyIDOverride string
+
 func newDotloopClient() (*dotloopClient, error) {
 	token, err := config.MustGet("dotloop_token")
 	if err != nil {
 		return nil, err
 	}
 
-	companyID, _ := config.Get("dotloop_company_id")
+	companyID := companyIDOverride
+	if companyID == "" {
+		companyID, _ = config.Get("dotloop_company_id")
+	}
 
 	return &dotloopClient{
 		token:      token,
 		companyID:  companyID,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: &http.Client{},
 	}, nil
 }
 
+// scoped adds the company_id query parameter to a list endpoint when a
+// company is configured, so results from other companies are excluded
+func (c *dotloopClient) scoped(endpoint string) string {
+	if c.companyID == "" {
+		return endpoint
+	}
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	return endpoint + sep + "company_id=" + url.QueryEscape(c.companyID)
+}
+
 func (c *dotloopClient) doRequest(method, endpoint string, body interface{}) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -73,12 +122,61 @@ func (c *dotloopClient) doRequest(method, endpoint string, body interface{}) ([]
 		return nil, err
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
 	req.Header.Set("Accept", "application/json")
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
+	return c.send(req)
+}
+
+// doUpload streams a file to the given endpoint as a multipart/form-data
+// request. The file is piped through the multipart writer so large documents
+// are never held in memory all at once.
+func (c *dotloopClient) doUpload(endpoint, filePath, fileName string) ([]byte, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		part, err := mw.CreateFormFile("file", fileName)
+		if err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		if _, err := io.Copy(part, f); err != nil {
+			pw.CloseWithError(err)
+			return
+		}
+		pw.CloseWithError(mw.Close())
+	}()
+
+	// Uploads can take much longer than regular API calls
+	ctx, cancel := context.WithTimeout(context.Background(), uploadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiBaseURL+endpoint, pr)
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	return c.send(req)
+}
+
+// send authorizes and executes a request, returning the response body or a
+// descriptive error for non-2xx responses.
+func (c *dotloopClient) send(req *http.Request) ([]byte, error) {
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -91,28 +189,127 @@ func (c *dotloopClient) doRequest(method, endpoint string, body interface{}) ([]
 	}
 
 	if resp.StatusCode >= 400 {
-		var errResp struct {
+		return nil, responseError(resp, respBody)
+	}
+
+	return respBody, nil
+}
+
+// doDownload streams the document at endpoint to destPath, returning the
+// number of bytes written. The file is written under a temporary name and
+// renamed on success so an interrupted download never looks complete.
+func (c *dotloopClient) doDownload(endpoint, destPath string) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", apiBaseURL+endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/pdf")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return 0, responseError(resp, respBody)
+	}
+
+	tmpPath := destPath + ".part"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(f, resp.Body)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return 0, err
+	}
+	return n, os.Rename(tmpPath, destPath)
+}
+
+// apiError is a non-2xx DotLoop response. Messages holds every error the
+// API reported, so validation failures list all offending fields at once.
+type apiError struct {
+	StatusCode int
+	Messages   []string
+	RetryAfter string
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	if e.StatusCode == http.StatusTooManyRequests && len(e.Messages) == 0 {
+		return "DotLoop API rate limit exceeded"
+	}
+	if len(e.Messages) > 0 {
+		return "DotLoop API error: " + strings.Join(e.Messages, "; ")
+	}
+	return fmt.Sprintf("DotLoop API error (HTTP %d): %s", e.StatusCode, e.Body)
+}
+
+// responseError builds a descriptive error from a non-2xx response
+func responseError(resp *http.Response, respBody []byte) error {
+	apiErr := &apiError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: resp.Header.Get("Retry-After"),
+		Body:       string(respBody),
+	}
+
+	var errResp struct {
+		Message string `json:"message"`
+		Error   string `json:"error"`
+		Errors  []struct {
+			Field   string `json:"field"`
 			Message string `json:"message"`
-			Error   string `json:"error"`
-			Errors  []struct {
-				Message string `json:"message"`
-			} `json:"errors"`
+		} `json:"errors"`
+	}
+	if json.Unmarshal(respBody, &errResp) == nil {
+		if errResp.Message != "" {
+			apiErr.Messages = append(apiErr.Messages, errResp.Message)
 		}
-		if json.Unmarshal(respBody, &errResp) == nil {
-			if errResp.Message != "" {
-				return nil, fmt.Errorf("DotLoop API error: %s", errResp.Message)
-			}
-			if errResp.Error != "" {
-				return nil, fmt.Errorf("DotLoop API error: %s", errResp.Error)
-			}
-			if len(errResp.Errors) > 0 && errResp.Errors[0].Message != "" {
-				return nil, fmt.Errorf("DotLoop API error: %s", errResp.Errors[0].Message)
+		if errResp.Error != "" {
+			apiErr.Messages = append(apiErr.Messages, errResp.Error)
+		}
+		for _, e := range errResp.Errors {
+			switch {
+			case e.Message == "":
+			case e.Field != "":
+				apiErr.Messages = append(apiErr.Messages, e.Field+": "+e.Message)
+			default:
+				apiErr.Messages = append(apiErr.Messages, e.Message)
 			}
 		}
-		return nil, fmt.Errorf("DotLoop API error (HTTP %d): %s", resp.StatusCode, string(respBody))
 	}
+	return apiErr
+}
 
-	return respBody, nil
+// printAPIError prints err under code. Rate limits get the rate_limited code
+// with any Retry-After, and responses with several errors list them all.
+func printAPIError(code string, err error) error {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		return output.PrintError(code, err.Error(), nil)
+	}
+
+	details := map[string]any{"status": apiErr.StatusCode}
+	if len(apiErr.Messages) > 1 {
+		details["errors"] = apiErr.Messages
+	}
+	if apiErr.StatusCode == http.StatusTooManyRequests {
+		code = "rate_limited"
+		if apiErr.RetryAfter != "" {
+			details["retry_after"] = apiErr.RetryAfter
+		}
+	}
+	return output.PrintError(code, apiErr.Error(), details)
 }
 
 // Loop represents a DotLoop transaction
@@ -128,20 +325,32 @@ type Loop struct {
 
 // Profile represents a DotLoop profile
 type Profile struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Type  string `json:"type"`
-	Email string `json:"email,omitempty"`
-	Phone string `json:"phone,omitempty"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Email   string `json:"email,omitempty"`
+	Phone   string `json:"phone,omitempty"`
+	Default bool   `json:"default"`
 }
 
 // Task represents a DotLoop task
 type Task struct {
-	ID         string `json:"id"`
-	Name       string `json:"name"`
-	Status     string `json:"status"`
-	AssignedTo string `json:"assigned_to"`
-	DueDate    string `json:"due_date,omitempty"`
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	AssignedTo    string `json:"assigned_to"`
+	DueDate       string `json:"due_date,omitempty"`
+	CreatedDate   string `json:"created_date,omitempty"`
+	CompletedDate string `json:"completed_date,omitempty"`
+}
+
+// Participant represents a person involved in a DotLoop loop
+type Participant struct {
+	ID       string `json:"id"`
+	FullName string `json:"full_name"`
+	Email    string `json:"email,omitempty"`
+	Phone    string `json:"phone,omitempty"`
+	Role     string `json:"role"`
 }
 
 // Document represents a DotLoop document
@@ -151,6 +360,9 @@ type Document struct {
 	Type        string `json:"type"`
 	Size        int64  `json:"size"`
 	CreatedDate string `json:"created_date"`
+	// SignStatus is the e-signature state (pending, signed, declined); empty
+	// when not requested or the document was never sent for signature
+	SignStatus string `json:"sign_status,omitempty"`
 }
 
 func newLoopsCmd() *cobra.Command {
@@ -181,9 +393,9 @@ func newLoopsCmd() *cobra.Command {
 				endpoint += "?" + queryParams
 			}
 
-			body, err := client.doRequest("GET", endpoint, nil)
+			body, err := client.doRequest("GET", client.scoped(endpoint), nil)
 			if err != nil {
-				return output.PrintError("request_failed", err.Error(), nil)
+				return printAPIError("request_failed", err)
 			}
 
 			var result struct {
@@ -207,12 +419,27 @@ func newLoopsCmd() *cobra.Command {
 	return cmd
 }
 
+// loopExpansions maps each supported --expand value to its loop sub-resource
+var loopExpansions = map[string]string{
+	"participants": "/participants",
+	"tasks":        "/tasks",
+}
+
 func newLoopCmd() *cobra.Command {
+	var expand []string
+
 	cmd := &cobra.Command{
 		Use:   "loop [id]",
 		Short: "Get loop details",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, e := range expand {
+				if _, ok := loopExpansions[e]; !ok {
+					return output.PrintError("invalid_input", "Unknown expansion: "+e,
+						map[string]string{"supported": "participants, tasks"})
+				}
+			}
+
 			client, err := newDotloopClient()
 			if err != nil {
 				return err
@@ -220,7 +447,7 @@ func newLoopCmd() *cobra.Command {
 
 			body, err := client.doRequest("GET", "/loops/"+args[0], nil)
 			if err != nil {
-				return output.PrintError("request_failed", err.Error(), nil)
+				return printAPIError("request_failed", err)
 			}
 
 			var result struct {
@@ -231,15 +458,283 @@ func newLoopCmd() *cobra.Command {
 				return output.PrintError("parse_error", err.Error(), nil)
 			}
 
-			return output.Print(result.Loop)
+			if len(expand) == 0 {
+				return output.Print(result.Loop)
+			}
+
+			expanded, err := client.fetchLoopExpansions(args[0], expand)
+			if err != nil {
+				return printAPIError("request_failed", err)
+			}
+
+			response := map[string]any{"loop": result.Loop}
+			for k, v := range expanded {
+				response[k] = v
+			}
+
+			return output.Print(response)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&expand, "expand", nil, "Embed related data: participants, tasks")
+
+	return cmd
+}
+
+// fetchLoopExpansions concurrently fetches the requested loop sub-resources,
+// keyed by expansion name.
+func (c *dotloopClient) fetchLoopExpansions(loopID string, expand []string) (map[string]any, error) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]any)
+		errs    []string
+	)
+
+	for _, name := range expand {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+
+			var data any
+			switch name {
+			case "participants":
+				data = &[]Participant{}
+			case "tasks":
+				data = &[]Task{}
+			}
+
+			body, err := c.doRequest("GET", "/loops/"+loopID+loopExpansions[name], nil)
+			if err == nil {
+				err = json.Unmarshal(body, &struct {
+					Data any `json:"data"`
+				}{Data: data})
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, name+": "+err.Error())
+				return
+			}
+			results[name] = data
+		}(name)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to expand %s", strings.Join(errs, "; "))
+	}
+
+	return results, nil
+}
+
+// Activity is an entry in a loop's activity log
+type Activity struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Date    string `json:"date"`
+}
+
+// LoopSummary is a digest of what changed in a loop since a date
+type LoopSummary struct {
+	Loop               Loop       `json:"loop"`
+	Since              string     `json:"since"`
+	TasksCompleted     []Task     `json:"tasks_completed"`
+	TasksAdded         []Task     `json:"tasks_added"`
+	OpenTasks          int        `json:"open_tasks"`
+	DocumentsAdded     []Document `json:"documents_added"`
+	ParticipantChanges []Activity `json:"participant_changes"`
+}
+
+func newSummaryCmd() *cobra.Command {
+	var since string
+
+	cmd := &cobra.Command{
+		Use:   "summary [loop-id]",
+		Short: "Summarize loop activity since a date",
+		Long: `Build a single digest for a loop: its current status, tasks completed and
+added, documents added, and participant changes on or after --since. Useful
+for weekly deal reviews.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cutoff, err := time.ParseInLocation("2006-01-02", since, time.Local)
+			if err != nil {
+				return output.PrintError("invalid_input", "Invalid --since date, expected YYYY-MM-DD: "+since, nil)
+			}
+
+			client, err := newDotloopClient()
+			if err != nil {
+				return err
+			}
+
+			loopID := args[0]
+			var (
+				loop       Loop
+				tasks      []Task
+				documents  []Document
+				activities []Activity
+			)
+			targets := map[string]any{
+				"":           &loop,
+				"/tasks":     &tasks,
+				"/documents": &documents,
+				"/activity?since=" + url.QueryEscape(since): &activities,
+			}
+
+			var (
+				mu       sync.Mutex
+				wg       sync.WaitGroup
+				firstErr error
+			)
+			for path, data := range targets {
+				wg.Add(1)
+				go func(path string, data any) {
+					defer wg.Done()
+					body, err := client.doRequest("GET", "/loops/"+loopID+path, nil)
+					if err == nil {
+						err = json.Unmarshal(body, &struct {
+							Data any `json:"data"`
+						}{Data: data})
+					}
+					if err != nil {
+						mu.Lock()
+						if firstErr == nil {
+							firstErr = err
+						}
+						mu.Unlock()
+					}
+				}(path, data)
+			}
+			wg.Wait()
+
+			if firstErr != nil {
+				return printAPIError("request_failed", firstErr)
+			}
+
+			return output.Print(summarizeLoop(loop, tasks, documents, activities, cutoff))
 		},
 	}
 
+	cmd.Flags().StringVar(&since, "since", "", "Include changes on or after this date (YYYY-MM-DD, required)")
+	_ = cmd.MarkFlagRequired("since")
+
 	return cmd
 }
 
+// summarizeLoop filters a loop's tasks, documents, and activity down to what
+// changed on or after cutoff
+func summarizeLoop(loop Loop, tasks []Task, documents []Document, activities []Activity, cutoff time.Time) LoopSummary {
+	summary := LoopSummary{
+		Loop:               loop,
+		Since:              cutoff.Format("2006-01-02"),
+		TasksCompleted:     []Task{},
+		TasksAdded:         []Task{},
+		DocumentsAdded:     []Document{},
+		ParticipantChanges: []Activity{},
+	}
+
+	for _, t := range tasks {
+		if onOrAfter(t.CompletedDate, cutoff) {
+			summary.TasksCompleted = append(summary.TasksCompleted, t)
+		}
+		if onOrAfter(t.CreatedDate, cutoff) {
+			summary.TasksAdded = append(summary.TasksAdded, t)
+		}
+		if taskOpen(t) {
+			summary.OpenTasks++
+		}
+	}
+	for _, d := range documents {
+		if onOrAfter(d.CreatedDate, cutoff) {
+			summary.DocumentsAdded = append(summary.DocumentsAdded, d)
+		}
+	}
+	for _, a := range activities {
+		if strings.Contains(strings.ToLower(a.Type), "participant") && onOrAfter(a.Date, cutoff) {
+			summary.ParticipantChanges = append(summary.ParticipantChanges, a)
+		}
+	}
+
+	return summary
+}
+
+// onOrAfter reports whether a DotLoop timestamp falls on or after cutoff.
+// Empty or unparseable timestamps never match.
+func onOrAfter(ts string, cutoff time.Time) bool {
+	t, ok := parseTimestamp(ts)
+	return ok && !t.Before(cutoff)
+}
+
+// parseTimestamp parses the timestamp layouts DotLoop returns, reading those
+// without an offset in local time
+func parseTimestamp(ts string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, ts, time.Local); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// taskOpen reports whether a task has not been completed
+func taskOpen(t Task) bool {
+	return t.CompletedDate == "" && !strings.EqualFold(t.Status, "completed")
+}
+
+// taskDue returns when a task falls due. A date-only due date means the end
+// of that day, so a task due today is not yet overdue.
+func taskDue(t Task) (time.Time, bool) {
+	due, ok := parseTimestamp(t.DueDate)
+	if ok && len(t.DueDate) == len("2006-01-02") {
+		due = due.AddDate(0, 0, 1)
+	}
+	return due, ok
+}
+
+// filterTasksByDue keeps open tasks that are overdue at now (when overdue is
+// set) or fall due within the next window (when window > 0). Tasks without a
+// parseable due date are always dropped.
+func filterTasksByDue(tasks []Task, now time.Time, overdue bool, window time.Duration) []Task {
+	filtered := []Task{}
+	for _, t := range tasks {
+		if !taskOpen(t) {
+			continue
+		}
+		due, ok := taskDue(t)
+		if !ok {
+			continue
+		}
+		isOverdue := due.Before(now)
+		upcoming := !isOverdue && window > 0 && !due.After(now.Add(window))
+		if (overdue && isOverdue) || upcoming {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered
+}
+
+// parseWindow parses a --due-within value: a Go duration such as 36h, or a
+// whole number of days such as 7d
+func parseWindow(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid window %q, expected e.g. 7d or 36h", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid window %q, expected e.g. 7d or 36h", s)
+	}
+	return d, nil
+}
+
 func newProfilesCmd() *cobra.Command {
 	var limit int
+	var defaultOnly bool
 
 	cmd := &cobra.Command{
 		Use:   "profiles",
@@ -250,32 +745,206 @@ func newProfilesCmd() *cobra.Command {
 				return err
 			}
 
-			endpoint := "/profiles"
-			if limit > 0 {
-				endpoint += "?limit=" + fmt.Sprint(limit)
+			if defaultOnly {
+				profile, err := client.defaultProfile()
+				if err != nil {
+					return err
+				}
+				return output.Print(profile)
 			}
 
-			body, err := client.doRequest("GET", endpoint, nil)
+			profiles, err := client.listProfiles(limit)
 			if err != nil {
-				return output.PrintError("request_failed", err.Error(), nil)
+				return err
+			}
+
+			return output.Print(map[string]any{
+				"count":    len(profiles),
+				"profiles": profiles,
+			})
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of results")
+	cmd.Flags().BoolVar(&defaultOnly, "default", false, "Return only the account's default profile")
+
+	return cmd
+}
+
+// listProfiles fetches the account's profiles, printing any failure
+func (c *dotloopClient) listProfiles(limit int) ([]Profile, error) {
+	endpoint := "/profiles"
+	if limit > 0 {
+		endpoint += "?limit=" + fmt.Sprint(limit)
+	}
+
+	body, err := c.doRequest("GET", c.scoped(endpoint), nil)
+	if err != nil {
+		return nil, printAPIError("request_failed", err)
+	}
+
+	var result struct {
+		Profiles []Profile `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, output.PrintError("parse_error", err.Error(), nil)
+	}
+
+	return result.Profiles, nil
+}
+
+// defaultProfile returns the profile flagged as the account default,
+// printing a not_found error when none is
+func (c *dotloopClient) defaultProfile() (Profile, error) {
+	profiles, err := c.listProfiles(0)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	for _, p := range profiles {
+		if p.Default {
+			return p, nil
+		}
+	}
+
+	return Profile{}, output.PrintError("not_found", "No default profile is set on this account", nil)
+}
+
+// resolveProfileID returns id unchanged, or the default profile's ID when
+// id is "default"
+func (c *dotloopClient) resolveProfileID(id string) (string, error) {
+	if !strings.EqualFold(id, "default") {
+		return id, nil
+	}
+	profile, err := c.defaultProfile()
+	if err != nil {
+		return "", err
+	}
+	return profile.ID, nil
+}
+
+// profileTypes are the profile types DotLoop accepts on creation
+var profileTypes = []string{"INDIVIDUAL", "TEAM", "OFFICE", "COMPANY"}
+
+func newCreateProfileCmd() *cobra.Command {
+	var name string
+	var profileType string
+	var company string
+	var phone string
+
+	cmd := &cobra.Command{
+		Use:   "create-profile",
+		Short: "Create a profile, e.g. for an agent joining the brokerage",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profileType = strings.ToUpper(strings.TrimSpace(profileType))
+			valid := false
+			for _, t := range profileTypes {
+				if profileType == t {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return output.PrintError("invalid_input",
+					fmt.Sprintf("Unsupported profile type: %s", profileType),
+					map[string]any{"supported": profileTypes})
+			}
+
+			client, err := newDotloopClient()
+			if err != nil {
+				return err
+			}
+
+			reqBody := map[string]string{
+				"name": name,
+				"type": profileType,
+			}
+			if company != "" {
+				reqBody["company"] = company
+			}
+			if phone != "" {
+				reqBody["phone"] = phone
+			}
+
+			body, err := client.doRequest("POST", "/profile", reqBody)
+			if err != nil {
+				return printAPIError("request_failed", err)
 			}
 
 			var result struct {
-				Profiles []Profile `json:"data"`
+				Profile Profile `json:"data"`
 			}
 
 			if err := json.Unmarshal(body, &result); err != nil {
 				return output.PrintError("parse_error", err.Error(), nil)
 			}
 
-			return output.Print(map[string]any{
-				"count":    len(result.Profiles),
-				"profiles": result.Profiles,
-			})
+			return output.Print(result.Profile)
 		},
 	}
 
-	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of results")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Profile name, usually the agent's name (required)")
+	cmd.Flags().StringVarP(&profileType, "type", "t", "INDIVIDUAL", "Profile type: "+strings.Join(profileTypes, ", "))
+	cmd.Flags().StringVar(&company, "company", "", "Company name shown on the profile")
+	cmd.Flags().StringVar(&phone, "phone", "", "Profile phone number")
+	_ = cmd.MarkFlagRequired("name")
+
+	return cmd
+}
+
+func newCreateLoopCmd() *cobra.Command {
+	var name string
+	var profileID string
+	var transactionType string
+	var status string
+
+	cmd := &cobra.Command{
+		Use:   "create-loop",
+		Short: "Create a loop (transaction) under a profile",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newDotloopClient()
+			if err != nil {
+				return err
+			}
+
+			id, err := client.resolveProfileID(profileID)
+			if err != nil {
+				return err
+			}
+
+			reqBody := map[string]string{
+				"name": name,
+			}
+			if transactionType != "" {
+				reqBody["transactionType"] = transactionType
+			}
+			if status != "" {
+				reqBody["status"] = status
+			}
+
+			body, err := client.doRequest("POST", "/profiles/"+id+"/loops", reqBody)
+			if err != nil {
+				return printAPIError("request_failed", err)
+			}
+
+			var result struct {
+				Loop Loop `json:"data"`
+			}
+
+			if err := json.Unmarshal(body, &result); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(result.Loop)
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Loop name, usually the property address (required)")
+	cmd.Flags().StringVarP(&profileID, "profile", "p", "default", "Profile ID, or \"default\" for the account's default profile")
+	cmd.Flags().StringVarP(&transactionType, "type", "t", "", "Transaction type, e.g. PURCHASE_OFFER, LISTING_FOR_SALE")
+	cmd.Flags().StringVarP(&status, "status", "s", "", "Initial status, e.g. PRE_OFFER, PRE_LISTING")
+	_ = cmd.MarkFlagRequired("name")
 
 	return cmd
 }
@@ -283,11 +952,28 @@ func newProfilesCmd() *cobra.Command {
 func newTasksCmd() *cobra.Command {
 	var limit int
 	var status string
+	var overdue bool
+	var dueWithin string
 
 	cmd := &cobra.Command{
 		Use:   "tasks",
 		Short: "List tasks across loops",
+		Long: `List tasks across loops.
+
+--overdue keeps incomplete tasks whose due date has passed and --due-within
+keeps incomplete tasks due in the given window (e.g. 7d or 36h); together
+they return both. Tasks without a due date are excluded by either filter.
+Filtering happens after fetching, so raise --limit to search further.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			var window time.Duration
+			if dueWithin != "" {
+				w, err := parseWindow(dueWithin)
+				if err != nil {
+					return output.PrintError("invalid_input", err.Error(), nil)
+				}
+				window = w
+			}
+
 			client, err := newDotloopClient()
 			if err != nil {
 				return err
@@ -308,9 +994,9 @@ func newTasksCmd() *cobra.Command {
 				endpoint += "?" + queryParams
 			}
 
-			body, err := client.doRequest("GET", endpoint, nil)
+			body, err := client.doRequest("GET", client.scoped(endpoint), nil)
 			if err != nil {
-				return output.PrintError("request_failed", err.Error(), nil)
+				return printAPIError("request_failed", err)
 			}
 
 			var result struct {
@@ -321,21 +1007,83 @@ func newTasksCmd() *cobra.Command {
 				return output.PrintError("parse_error", err.Error(), nil)
 			}
 
+			tasks := result.Tasks
+			if overdue || window > 0 {
+				tasks = filterTasksByDue(tasks, time.Now(), overdue, window)
+			}
+
 			return output.Print(map[string]any{
-				"count": len(result.Tasks),
-				"tasks": result.Tasks,
+				"count": len(tasks),
+				"tasks": tasks,
 			})
 		},
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of results")
 	cmd.Flags().StringVarP(&status, "status", "s", "", "Filter by status")
+	cmd.Flags().BoolVar(&overdue, "overdue", false, "Only incomplete tasks past their due date")
+	cmd.Flags().StringVar(&dueWithin, "due-within", "", "Only incomplete tasks due within this window, e.g. 7d")
+
+	return cmd
+}
+
+func newCompleteTaskCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "complete-task [loop-id] [task-id]",
+		Short: "Mark a loop task as completed",
+		Long: `Mark a task on a loop's checklist as completed and return the updated task.
+Tasks that are already completed are returned unchanged without an update.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newDotloopClient()
+			if err != nil {
+				return err
+			}
+
+			endpoint := "/loops/" + args[0] + "/tasks/" + args[1]
+			body, err := client.doRequest("GET", endpoint, nil)
+			if err != nil {
+				var apiErr *apiError
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+					return output.PrintError("not_found", "Task not found: "+args[1],
+						map[string]string{"loop_id": args[0], "task_id": args[1]})
+				}
+				return printAPIError("request_failed", err)
+			}
+
+			var current struct {
+				Task Task `json:"data"`
+			}
+			if err := json.Unmarshal(body, &current); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+			if !taskOpen(current.Task) {
+				return output.Print(current.Task)
+			}
+
+			body, err = client.doRequest("PUT", endpoint, map[string]string{"status": "COMPLETED"})
+			if err != nil {
+				return printAPIError("request_failed", err)
+			}
+
+			var updated struct {
+				Task Task `json:"data"`
+			}
+			if err := json.Unmarshal(body, &updated); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(updated.Task)
+		},
+	}
 
 	return cmd
 }
 
 func newDocumentsCmd() *cobra.Command {
 	var limit int
+	var includeSignStatus bool
+	var pendingOnly bool
 
 	cmd := &cobra.Command{
 		Use:   "documents [loop-id]",
@@ -347,14 +1095,21 @@ func newDocumentsCmd() *cobra.Command {
 				return err
 			}
 
-			endpoint := "/loops/" + args[0] + "/documents"
+			params := url.Values{}
 			if limit > 0 {
-				endpoint += "?limit=" + fmt.Sprint(limit)
+				params.Set("limit", fmt.Sprint(limit))
+			}
+			if includeSignStatus || pendingOnly {
+				params.Set("include", "esign_status")
+			}
+			endpoint := "/loops/" + args[0] + "/documents"
+			if len(params) > 0 {
+				endpoint += "?" + params.Encode()
 			}
 
 			body, err := client.doRequest("GET", endpoint, nil)
 			if err != nil {
-				return output.PrintError("request_failed", err.Error(), nil)
+				return printAPIError("request_failed", err)
 			}
 
 			var result struct {
@@ -365,15 +1120,437 @@ func newDocumentsCmd() *cobra.Command {
 				return output.PrintError("parse_error", err.Error(), nil)
 			}
 
+			documents := result.Documents
+			for i := range documents {
+				documents[i].SignStatus = strings.ToLower(documents[i].SignStatus)
+			}
+			if pendingOnly {
+				documents = filterPendingSignature(documents)
+			}
+
 			return output.Print(map[string]any{
 				"loop_id":   args[0],
-				"count":     len(result.Documents),
-				"documents": result.Documents,
+				"count":     len(documents),
+				"documents": documents,
 			})
 		},
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "l", 50, "Number of results")
+	cmd.Flags().BoolVar(&includeSignStatus, "include-esign-status", false, "Include each document's e-signature status")
+	cmd.Flags().BoolVar(&pendingOnly, "pending-only", false, "Only documents still awaiting signatures (implies --include-esign-status)")
+
+	return cmd
+}
+
+// filterPendingSignature keeps documents whose signatures are still outstanding
+func filterPendingSignature(docs []Document) []Document {
+	pending := []Document{}
+	for _, d := range docs {
+		if d.SignStatus == "pending" {
+			pending = append(pending, d)
+		}
+	}
+	return pending
+}
+
+func newUploadCmd() *cobra.Command {
+	var filePath string
+	var folderID string
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "upload [loop-id]",
+		Short: "Upload a document to a loop folder",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := os.Stat(filePath)
+			if err != nil {
+				return output.PrintError("file_not_found", err.Error(), map[string]string{"file": filePath})
+			}
+			if info.IsDir() {
+				return output.PrintError("invalid_file", "Path is a directory: "+filePath, nil)
+			}
+
+			client, err := newDotloopClient()
+			if err != nil {
+				return err
+			}
+
+			if name == "" {
+				name = filepath.Base(filePath)
+			}
+
+			endpoint := "/loops/" + args[0] + "/folders/" + folderID + "/documents"
+			body, err := client.doUpload(endpoint, filePath, name)
+			if err != nil {
+				return printAPIError("upload_failed", err)
+			}
+
+			var result struct {
+				Document Document `json:"data"`
+			}
+
+			if err := json.Unmarshal(body, &result); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(result.Document)
+		},
+	}
+
+	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to the file to upload (required)")
+	cmd.Flags().StringVar(&folderID, "folder", "", "Folder ID within the loop (required)")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Document name (defaults to the file name)")
+	_ = cmd.MarkFlagRequired("file")
+	_ = cmd.MarkFlagRequired("folder")
+
+	return cmd
+}
+
+// Folder is a loop folder with the documents it contains
+type Folder struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Documents []Document `json:"documents"`
+}
+
+// DownloadResult reports the outcome of downloading one document
+type DownloadResult struct {
+	DocumentID string `json:"document_id"`
+	Folder     string `json:"folder,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Bytes      int64  `json:"bytes,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func newDownloadCmd() *cobra.Command {
+	var folderID string
+	var documentID string
+	var all bool
+	var out string
+	var concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "download [loop-id]",
+		Short: "Download a document, or every document in a loop",
+		Long: `Download a single document with --folder and --document, saving it to --out
+(a file path, or a directory to keep the document's name).
+
+With --all, every document in every folder of the loop is downloaded into the
+--out directory as <folder>/<document>.pdf, with bounded concurrency. A folder
+or document whose name collides with an earlier one gets its ID appended, e.g.
+"Offers (123)". Failures are reported per document without aborting the rest.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loopID := args[0]
+			if !all && (folderID == "" || documentID == "") {
+				return output.PrintError("invalid_input", "Provide --folder and --document, or --all", nil)
+			}
+			if concurrency < 1 {
+				concurrency = 1
+			}
+
+			client, err := newDotloopClient()
+			if err != nil {
+				return err
+			}
+
+			if !all {
+				dest := out
+				if info, err := os.Stat(out); err == nil && info.IsDir() {
+					dest = filepath.Join(out, documentFileName(documentID, ""))
+				}
+				endpoint := "/loops/" + loopID + "/folders/" + folderID + "/documents/" + documentID
+				n, err := client.doDownload(endpoint, dest)
+				if err != nil {
+					return printAPIError("download_failed", err)
+				}
+				return output.Print(DownloadResult{DocumentID: documentID, Path: dest, Bytes: n})
+			}
+
+			body, err := client.doRequest("GET", "/loops/"+loopID+"/folders?include_documents=true", nil)
+			if err != nil {
+				return printAPIError("request_failed", err)
+			}
+
+			var result struct {
+				Folders []Folder `json:"data"`
+			}
+			if err := json.Unmarshal(body, &result); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			jobs := planDownloads(out, result.Folders)
+			results := make([]DownloadResult, len(jobs))
+			sem := make(chan struct{}, concurrency)
+			var wg sync.WaitGroup
+			for i, j := range jobs {
+				wg.Add(1)
+				go func(i int, j downloadJob) {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+
+					r := DownloadResult{DocumentID: j.doc.ID, Folder: j.folder.Name, Path: j.path}
+					err := os.MkdirAll(filepath.Dir(j.path), 0o755)
+					if err == nil {
+						endpoint := "/loops/" + loopID + "/folders/" + j.folder.ID + "/documents/" + j.doc.ID
+						r.Bytes, err = client.doDownload(endpoint, j.path)
+					}
+					if err != nil {
+						r.Path = ""
+						r.Error = err.Error()
+					}
+					results[i] = r
+				}(i, j)
+			}
+			wg.Wait()
+
+			failed := 0
+			var total int64
+			for _, r := range results {
+				if r.Error != "" {
+					failed++
+				}
+				total += r.Bytes
+			}
+
+			return output.Print(map[string]any{
+				"loop_id":    loopID,
+				"directory":  out,
+				"folders":    len(result.Folders),
+				"downloaded": len(results) - failed,
+				"failed":     failed,
+				"bytes":      total,
+				"documents":  results,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&folderID, "folder", "", "Folder ID containing the document")
+	cmd.Flags().StringVar(&documentID, "document", "", "Document ID to download")
+	cmd.Flags().BoolVar(&all, "all", false, "Download every document in the loop")
+	cmd.Flags().StringVar(&out, "out", ".", "Destination file or directory")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "c", 4, "Maximum concurrent downloads (with --all)")
+
+	return cmd
+}
+
+// downloadJob is one document fetched by download --all
+type downloadJob struct {
+	folder Folder
+	doc    Document
+	path   string
+}
+
+// planDownloads maps every document in folders to out/<folder>/<document>.
+// Paths are deduped case-insensitively across the whole loop: a folder whose
+// directory name is taken gets its ID appended, and so does a document whose
+// path is taken, so no two downloads write the same file.
+func planDownloads(out string, folders []Folder) []downloadJob {
+	usedDirs := make(map[string]bool)
+	usedPaths := make(map[string]bool)
+	var jobs []downloadJob
+	for _, folder := range folders {
+		dirName := sanitizeFileName(folder.Name, folder.ID)
+		if usedDirs[strings.ToLower(dirName)] {
+			dirName += " (" + folder.ID + ")"
+		}
+		usedDirs[strings.ToLower(dirName)] = true
+		dir := filepath.Join(out, dirName)
+
+		for _, doc := range folder.Documents {
+			name := documentFileName(doc.ID, doc.Name)
+			path := filepath.Join(dir, name)
+			if usedPaths[strings.ToLower(path)] {
+				ext := filepath.Ext(name)
+				path = filepath.Join(dir, strings.TrimSuffix(name, ext)+" ("+doc.ID+")"+ext)
+			}
+			usedPaths[strings.ToLower(path)] = true
+			jobs = append(jobs, downloadJob{folder: folder, doc: doc, path: path})
+		}
+	}
+	return jobs
+}
+
+// documentFileName returns a safe file name for a document, falling back to
+// its ID and adding a .pdf extension when the name has none
+func documentFileName(id, name string) string {
+	name = sanitizeFileName(name, id)
+	if filepath.Ext(name) == "" {
+		name += ".pdf"
+	}
+	return name
+}
+
+// sanitizeFileName makes a DotLoop folder or document name safe to use as a
+// single path element, using fallback when nothing usable is left
+func sanitizeFileName(name, fallback string) string {
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		if r < 0x20 {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.Trim(strings.TrimSpace(name), ".")
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// LoopFinancials holds the financial detail fields of a loop.
+// Fields that are not populated in DotLoop are null.
+type LoopFinancials struct {
+	LoopID          string   `json:"loop_id"`
+	PurchasePrice   *float64 `json:"purchase_price"`
+	CommissionRate  *float64 `json:"commission_rate"`
+	CommissionTotal *float64 `json:"commission_total"`
+	CloseDate       *string  `json:"close_date"`
+}
+
+func newFinancialsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "financials [loop-id]",
+		Short: "Get loop financials (price, commission, close date)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newDotloopClient()
+			if err != nil {
+				return err
+			}
+
+			body, err := client.doRequest("GET", "/loops/"+args[0]+"/detail", nil)
+			if err != nil {
+				return printAPIError("request_failed", err)
+			}
+
+			var result struct {
+				Data map[string]map[string]string `json:"data"`
+			}
+
+			if err := json.Unmarshal(body, &result); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(parseFinancials(args[0], result.Data))
+		},
+	}
+
+	return cmd
+}
+
+// parseFinancials extracts typed financial fields from loop detail sections
+func parseFinancials(loopID string, sections map[string]map[string]string) LoopFinancials {
+	fin := LoopFinancials{LoopID: loopID}
+
+	financials := sections["Financials"]
+	fin.PurchasePrice = parseAmount(financials["Purchase/Sale Price"])
+	fin.CommissionRate = parseAmount(financials["Sale Commission Rate"])
+	fin.CommissionTotal = parseAmount(financials["Sale Commission Total"])
+
+	if d := strings.TrimSpace(sections["Contract Dates"]["Closing Date"]); d != "" {
+		fin.CloseDate = &d
+	}
+
+	return fin
+}
+
+// parseAmount parses values like "$450,000.00" or "3%" into a number,
+// returning nil for empty or unparseable values.
+func parseAmount(v string) *float64 {
+	v = strings.NewReplacer("$", "", ",", "", "%", "", " ", "").Replace(v)
+	if v == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil
+	}
+	return &f
+}
+
+func newAddParticipantCmd() *cobra.Command {
+	var name string
+	var email string
+	var phone string
+	var role string
+
+	cmd := &cobra.Command{
+		Use:   "add-participant [loop-id]",
+		Short: "Add a participant to a loop",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newDotloopClient()
+			if err != nil {
+				return err
+			}
+
+			reqBody := map[string]string{
+				"fullName": name,
+				"role":     role,
+			}
+			if email != "" {
+				reqBody["email"] = email
+			}
+			if phone != "" {
+				reqBody["phone"] = phone
+			}
+
+			body, err := client.doRequest("POST", "/loops/"+args[0]+"/participants", reqBody)
+			if err != nil {
+				return printAPIError("request_failed", err)
+			}
+
+			var result struct {
+				Participant Participant `json:"data"`
+			}
+
+			if err := json.Unmarshal(body, &result); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(result.Participant)
+		},
+	}
+
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Participant full name (required)")
+	cmd.Flags().StringVarP(&email, "email", "e", "", "Participant email")
+	cmd.Flags().StringVarP(&phone, "phone", "p", "", "Participant phone")
+	cmd.Flags().StringVarP(&role, "role", "r", "", "Participant role, e.g. BUYER, SELLER, LENDER (required)")
+	_ = cmd.MarkFlagRequired("name")
+	_ = cmd.MarkFlagRequired("role")
+
+	return cmd
+}
+
+func newRemoveParticipantCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove-participant [loop-id] [participant-id]",
+		Short: "Remove a participant from a loop",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newDotloopClient()
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.doRequest("DELETE", "/loops/"+args[0]+"/participants/"+args[1], nil); err != nil {
+				return printAPIError("request_failed", err)
+			}
+
+			return output.Print(map[string]any{
+				"loop_id":        args[0],
+				"participant_id": args[1],
+				"removed":        true,
+			})
+		},
+	}
 
 	return cmd
 }
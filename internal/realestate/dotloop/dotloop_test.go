@@ -0,0 +1,312 @@
+package dotloop
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"7d", 7 * 24 * time.Hour, false},
+		{"1d", 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"0d", 0, true},
+		{"-2d", 0, true},
+		{"xd", 0, true},
+		{"0s", 0, true},
+		{"week", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseWindow(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseWindow(%q) = %v, %v; want %v, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestTaskDue(t *testing.T) {
+	tests := []struct {
+		due  string
+		want time.Time
+		ok   bool
+	}{
+		// A date-only due date runs to the end of that day
+		{"2024-06-10", time.Date(2024, 6, 11, 0, 0, 0, 0, time.Local), true},
+		{"2024-06-10T15:30:00", time.Date(2024, 6, 10, 15, 30, 0, 0, time.Local), true},
+		{"2024-06-10 15:30:00", time.Date(2024, 6, 10, 15, 30, 0, 0, time.Local), true},
+		{"2024-06-10T15:30:00Z", time.Date(2024, 6, 10, 15, 30, 0, 0, time.UTC), true},
+		{"", time.Time{}, false},
+		{"June 10", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := taskDue(Task{DueDate: tt.due})
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("taskDue(%q) = %v, %v; want %v, %v", tt.due, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFilterTasksByDue(t *testing.T) {
+	now := time.Date(2024, 6, 10, 12, 0, 0, 0, time.Local)
+	tasks := []Task{
+		{ID: "due-today", DueDate: "2024-06-10"},
+		{ID: "overdue", DueDate: "2024-06-09"},
+		{ID: "earlier-today", DueDate: "2024-06-10T09:00:00"},
+		{ID: "in-3-days", DueDate: "2024-06-13"},
+		{ID: "in-30-days", DueDate: "2024-07-10"},
+		{ID: "done", DueDate: "2024-06-01", Status: "COMPLETED"},
+		{ID: "done-dated", DueDate: "2024-06-01", CompletedDate: "2024-06-02"},
+		{ID: "no-date"},
+	}
+
+	tests := []struct {
+		name    string
+		overdue bool
+		window  time.Duration
+		want    []string
+	}{
+		{"overdue", true, 0, []string{"overdue", "earlier-today"}},
+		{"within 7d", false, 7 * 24 * time.Hour, []string{"due-today", "in-3-days"}},
+		{"both", true, 7 * 24 * time.Hour, []string{"due-today", "overdue", "earlier-today", "in-3-days"}},
+		{"neither", false, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterTasksByDue(tasks, now, tt.overdue, tt.window)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d tasks %+v, want %v", len(got), got, tt.want)
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("task %d = %s, want %s", i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"$450,000.00", 450000, true},
+		{"3%", 3, true},
+		{"2.5 %", 2.5, true},
+		{"13500", 13500, true},
+		{"", 0, false},
+		{"  ", 0, false},
+		{"TBD", 0, false},
+	}
+	for _, tt := range tests {
+		got := parseAmount(tt.in)
+		if (got != nil) != tt.ok || (got != nil && *got != tt.want) {
+			t.Errorf("parseAmount(%q) = %v, want %v (ok=%v)", tt.in, got, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseFinancials(t *testing.T) {
+	fin := parseFinancials("42", map[string]map[string]string{
+		"Financials": {
+			"Purchase/Sale Price":   "$450,000.00",
+			"Sale Commission Rate":  "3%",
+			"Sale Commission Total": "",
+		},
+		"Contract Dates": {"Closing Date": " 2024-07-01 "},
+	})
+
+	if fin.LoopID != "42" || fin.PurchasePrice == nil || *fin.PurchasePrice != 450000 {
+		t.Errorf("purchase price = %+v", fin)
+	}
+	if fin.CommissionRate == nil || *fin.CommissionRate != 3 {
+		t.Errorf("commission rate = %v, want 3", fin.CommissionRate)
+	}
+	if fin.CommissionTotal != nil {
+		t.Errorf("empty commission total = %v, want nil", *fin.CommissionTotal)
+	}
+	if fin.CloseDate == nil || *fin.CloseDate != "2024-07-01" {
+		t.Errorf("close date = %v, want 2024-07-01", fin.CloseDate)
+	}
+
+	if empty := parseFinancials("7", nil); empty.PurchasePrice != nil || empty.CloseDate != nil {
+		t.Errorf("no sections = %+v, want nulls", empty)
+	}
+}
+
+func TestDocumentFileName(t *testing.T) {
+	tests := []struct {
+		id, name string
+		want     string
+	}{
+		{"1", "Purchase Agreement.pdf", "Purchase Agreement.pdf"},
+		{"2", "Addendum", "Addendum.pdf"},
+		{"3", "a/b:c*d?.docx", "a_b_c_d_.docx"},
+		{"4", "  ..  ", "4.pdf"},
+		{"5", "line\nbreak", "linebreak.pdf"},
+		{"6", "", "6.pdf"},
+	}
+	for _, tt := range tests {
+		if got := documentFileName(tt.id, tt.name); got != tt.want {
+			t.Errorf("documentFileName(%q, %q) = %q, want %q", tt.id, tt.name, got, tt.want)
+		}
+	}
+	if got := sanitizeFileName(`Offers <2024>|"final"`, "folder"); got != "Offers _2024___final_" {
+		t.Errorf("sanitizeFileName = %q", got)
+	}
+}
+
+func captureStdout(fn func()) string {
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	fn()
+
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	_, _ = io.Copy(&buf, r)
+	return buf.String()
+}
+
+func TestResponseError(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusBadRequest, Header: http.Header{}}
+	body := `{"message":"Validation failed","errors":[{"field":"name","message":"is required"},{"message":"status is invalid"},{"field":"x"}]}`
+
+	err := responseError(resp, []byte(body))
+	apiErr, ok := err.(*apiError)
+	if !ok {
+		t.Fatalf("err = %T, want *apiError", err)
+	}
+	want := []string{"Validation failed", "name: is required", "status is invalid"}
+	if strings.Join(apiErr.Messages, "|") != strings.Join(want, "|") {
+		t.Errorf("Messages = %q, want %q", apiErr.Messages, want)
+	}
+	if got := err.Error(); got != "DotLoop API error: Validation failed; name: is required; status is invalid" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	plain := responseError(&http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}, []byte("bad gateway"))
+	if got := plain.Error(); got != "DotLoop API error (HTTP 502): bad gateway" {
+		t.Errorf("non-JSON Error() = %q", got)
+	}
+}
+
+func TestPrintAPIError(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		body       string
+		wantCode   string
+		wantErrors int
+		wantRetry  string
+	}{
+		{"multiple errors", http.StatusUnprocessableEntity, "", `{"errors":[{"field":"name","message":"is required"},{"field":"status","message":"is invalid"}]}`, "create_failed", 2, ""},
+		{"single error", http.StatusBadRequest, "", `{"message":"bad"}`, "create_failed", 0, ""},
+		{"rate limited", http.StatusTooManyRequests, "30", ``, "rate_limited", 0, "30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{StatusCode: tt.status, Header: http.Header{}}
+			if tt.retryAfter != "" {
+				resp.Header.Set("Retry-After", tt.retryAfter)
+			}
+
+			var printed error
+			out := captureStdout(func() {
+				printed = printAPIError("create_failed", responseError(resp, []byte(tt.body)))
+			})
+			if printed == nil {
+				t.Fatal("expected an error")
+			}
+
+			var got struct {
+				Error struct {
+					Code    string `json:"code"`
+					Details struct {
+						Status     int      `json:"status"`
+						Errors     []string `json:"errors"`
+						RetryAfter string   `json:"retry_after"`
+					} `json:"details"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("output %q: %v", out, err)
+			}
+			if got.Error.Code != tt.wantCode || got.Error.Details.Status != tt.status {
+				t.Errorf("code = %s, status = %d", got.Error.Code, got.Error.Details.Status)
+			}
+			if len(got.Error.Details.Errors) != tt.wantErrors {
+				t.Errorf("errors = %v, want %d", got.Error.Details.Errors, tt.wantErrors)
+			}
+			if got.Error.Details.RetryAfter != tt.wantRetry {
+				t.Errorf("retry_after = %q, want %q", got.Error.Details.RetryAfter, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestScoped(t *testing.T) {
+	tests := []struct {
+		companyID string
+		endpoint  string
+		want      string
+	}{
+		{"", "/profile/1/loop", "/profile/1/loop"},
+		{"77", "/profile/1/loop", "/profile/1/loop?company_id=77"},
+		{"77", "/profile/1/loop?batch_size=50", "/profile/1/loop?batch_size=50&company_id=77"},
+		{"a b&c", "/profile", "/profile?company_id=a+b%26c"},
+	}
+	for _, tt := range tests {
+		c := &dotloopClient{companyID: tt.companyID}
+		if got := c.scoped(tt.endpoint); got != tt.want {
+			t.Errorf("scoped(%q) with company %q = %q, want %q", tt.endpoint, tt.companyID, got, tt.want)
+		}
+	}
+}
+
+func TestPlanDownloads(t *testing.T) {
+	folders := []Folder{
+		{ID: "10", Name: "Offers", Documents: []Document{{ID: "1", Name: "Offer.pdf"}, {ID: "2", Name: "offer.pdf"}}},
+		{ID: "11", Name: "offers ", Documents: []Document{{ID: "3", Name: "Offer.pdf"}}},
+		{ID: "12", Name: "Disclosures", Documents: []Document{{ID: "4", Name: "Offer"}}},
+	}
+
+	jobs := planDownloads("out", folders)
+	want := []string{
+		filepath.Join("out", "Offers", "Offer.pdf"),
+		filepath.Join("out", "Offers", "offer (2).pdf"),
+		filepath.Join("out", "offers (11)", "Offer.pdf"),
+		filepath.Join("out", "Disclosures", "Offer.pdf"),
+	}
+	if len(jobs) != len(want) {
+		t.Fatalf("got %d jobs, want %d", len(jobs), len(want))
+	}
+	seen := map[string]bool{}
+	for i, j := range jobs {
+		if j.path != want[i] {
+			t.Errorf("job %d path = %q, want %q", i, j.path, want[i])
+		}
+		if seen[strings.ToLower(j.path)] {
+			t.Errorf("path %q planned twice", j.path)
+		}
+		seen[strings.ToLower(j.path)] = true
+	}
+}
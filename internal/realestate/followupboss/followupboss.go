@@ -3,10 +3,18 @@ package followupboss
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -25,9 +33,23 @@ func NewCmd() *cobra.Command {
 
 	cmd.AddCommand(newContactsCmd())
 	cmd.AddCommand(newContactCmd())
+	cmd.AddCommand(newNotesCmd())
+	cmd.AddCommand(newSearchCmd())
 	cmd.AddCommand(newLeadsCmd())
+	cmd.AddCommand(newCreateLeadCmd())
+	cmd.AddCommand(newCreateAppointmentCmd())
 	cmd.AddCommand(newTasksCmd())
+	cmd.AddCommand(newTaskCmd())
 	cmd.AddCommand(newEventsCmd())
+	cmd.AddCommand(newActionPlansCmd())
+	cmd.AddCommand(newAssignPlanCmd())
+	cmd.AddCommand(newBulkTagCmd())
+	cmd.AddCommand(newMergeCmd())
+	cmd.AddCommand(newDeleteContactCmd())
+	cmd.AddCommand(newWebhooksCmd())
+	cmd.AddCommand(newRegisterWebhookCmd())
+	cmd.AddCommand(newDeleteWebhookCmd())
+	cmd.AddCommand(newImportCmd())
 
 	return cmd
 }
@@ -38,8 +60,26 @@ type fubClient struct {
 	systemKey  string
 	systemName string
 	httpClient *http.Client
+
+	// rateLimitBuffer is the number of requests left in the current window
+	// at which paginated pulls pause until the window resets
+	rateLimitBuffer int
+
+	mu        sync.Mutex
+	rateLimit rateLimitState
+	pauses    int
+}
+
+// rateLimitState is what the most recent response said about the rate limit
+type rateLimitState struct {
+	seen      bool
+	remaining int
+	window    time.Duration
 }
 
+// defaultRateLimitWindow is the pause used when FUB omits X-RateLimit-Window
+const defaultRateLimitWindow = 10 * time.Second
+
 func newFUBClient() (*fubClient, error) {
 	apiKey, err := config.MustGet("fub_api_key")
 	if err != nil {
@@ -107,26 +147,229 @@ func (c *fubClient) doRequest(method, endpoint string, body interface{}) ([]byte
 	if err != nil {
 		return nil, err
 	}
+	c.recordRateLimit(resp.Header)
 
 	if resp.StatusCode >= 400 {
+		apiErr := &apiError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
 		var errResp struct {
 			Message string `json:"message"`
 			Error   string `json:"error"`
 		}
-		if json.Unmarshal(respBody, &errResp) == nil {
-			if errResp.Message != "" {
-				return nil, fmt.Errorf("FUB API error: %s", errResp.Message)
-			}
-			if errResp.Error != "" {
-				return nil, fmt.Errorf("FUB API error: %s", errResp.Error)
-			}
+		_ = json.Unmarshal(respBody, &errResp)
+		switch {
+		case errResp.Message != "":
+			apiErr.Message = "FUB API error: " + errResp.Message
+		case errResp.Error != "":
+			apiErr.Message = "FUB API error: " + errResp.Error
+		default:
+			apiErr.Message = fmt.Sprintf("FUB API error (HTTP %d): %s", resp.StatusCode, string(respBody))
 		}
-		return nil, fmt.Errorf("FUB API error (HTTP %d): %s", resp.StatusCode, string(respBody))
+		return nil, apiErr
 	}
 
 	return respBody, nil
 }
 
+// apiError is returned by doRequest for non-2xx responses
+type apiError struct {
+	StatusCode int
+	Message    string
+	// RetryAfter is the server-requested wait, when it sent Retry-After
+	RetryAfter time.Duration
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+// recordRateLimit keeps the X-RateLimit-* headers of a response, if present
+func (c *fubClient) recordRateLimit(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	state := rateLimitState{seen: true, remaining: remaining, window: defaultRateLimitWindow}
+	if secs, err := strconv.Atoi(h.Get("X-RateLimit-Window")); err == nil && secs > 0 {
+		state.window = time.Duration(secs) * time.Second
+	}
+
+	c.mu.Lock()
+	c.rateLimit = state
+	c.mu.Unlock()
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an
+// HTTP date, returning 0 when absent or invalid
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// paceRequests sleeps through the rest of the rate-limit window when the
+// last response left no more than rateLimitBuffer requests, so paginated
+// pulls slow down before FUB starts answering 429
+func (c *fubClient) paceRequests() {
+	c.mu.Lock()
+	state := c.rateLimit
+	c.mu.Unlock()
+
+	if !state.seen || state.remaining > c.rateLimitBuffer {
+		return
+	}
+	time.Sleep(state.window)
+
+	c.mu.Lock()
+	c.pauses++
+	c.rateLimit = rateLimitState{}
+	c.mu.Unlock()
+}
+
+// isRetryable reports whether a failed request may succeed if retried
+func isRetryable(err error) bool {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	// Network-level failures are worth retrying too
+	return err != nil
+}
+
+// isRateLimited reports whether a request was rejected with 429. FUB refuses
+// those before doing any work, so even a create is safe to send again.
+func isRateLimited(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// doRequestWithRetry retries transient failures with exponential backoff
+func (c *fubClient) doRequestWithRetry(method, endpoint string, body interface{}) ([]byte, error) {
+	return c.retryRequest(method, endpoint, body, isRetryable)
+}
+
+// doCreateWithRetry is doRequestWithRetry for requests that create records.
+// Only 429s are retried: a 5xx or timeout may arrive after FUB has already
+// created the record, and sending it again would make a duplicate.
+func (c *fubClient) doCreateWithRetry(method, endpoint string, body interface{}) ([]byte, error) {
+	return c.retryRequest(method, endpoint, body, isRateLimited)
+}
+
+// retryRequest sends a request, retrying with exponential backoff (or the
+// server's Retry-After) while retryable reports the failure as transient
+func (c *fubClient) retryRequest(method, endpoint string, body interface{}, retryable func(error) bool) ([]byte, error) {
+	const maxAttempts = 3
+	backoff := time.Second
+
+	var lastErr error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		respBody, err := c.doRequest(method, endpoint, body)
+		if err == nil {
+			return respBody, nil
+		}
+		lastErr = err
+		if !retryable(err) || attempt == maxAttempts {
+			break
+		}
+		wait := backoff
+		var apiErr *apiError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			wait = apiErr.RetryAfter
+		}
+		time.Sleep(wait)
+		backoff *= 2
+	}
+	return nil, lastErr
+}
+
+// fetchAllContacts pages through /contacts with the given filters until
+// every matching contact has been retrieved. Each page after the first is
+// paced against the rate limit reported by the previous one.
+func (c *fubClient) fetchAllContacts(params url.Values) ([]Contact, error) {
+	const pageSize = 100
+
+	var all []Contact
+	for offset := 0; ; offset += pageSize {
+		if offset > 0 {
+			c.paceRequests()
+		}
+		q := url.Values{}
+		for k, v := range params {
+			q[k] = v
+		}
+		q.Set("limit", fmt.Sprint(pageSize))
+		q.Set("offset", fmt.Sprint(offset))
+
+		body, err := c.doRequestWithRetry("GET", "/contacts?"+q.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Contacts []Contact `json:"contacts"`
+			Total    int       `json:"total"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, err
+		}
+
+		all = append(all, page.Contacts...)
+		if len(page.Contacts) < pageSize || (page.Total > 0 && len(all) >= page.Total) {
+			return all, nil
+		}
+	}
+}
+
+// fetchAllNotes pages through /notes for one person until every note has
+// been retrieved, pacing requests like fetchAllContacts.
+func (c *fubClient) fetchAllNotes(personID, sort string) ([]Note, error) {
+	const pageSize = 100
+
+	var all []Note
+	for offset := 0; ; offset += pageSize {
+		if offset > 0 {
+			c.paceRequests()
+		}
+		q := url.Values{}
+		q.Set("personId", personID)
+		q.Set("sort", sort)
+		q.Set("limit", fmt.Sprint(pageSize))
+		q.Set("offset", fmt.Sprint(offset))
+
+		body, err := c.doRequestWithRetry("GET", "/notes?"+q.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Notes []Note `json:"notes"`
+			Total int    `json:"total"`
+		}
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, err
+		}
+
+		all = append(all, page.Notes...)
+		if len(page.Notes) < pageSize || (page.Total > 0 && len(all) >= page.Total) {
+			return all, nil
+		}
+	}
+}
+
+// contactPath is the endpoint for a single contact, shared by every GET,
+// PUT, and DELETE so they address the same resource
+func contactPath(id string) string {
+	return "/contacts/" + url.PathEscape(id)
+}
+
 // Contact represents a Follow Up Boss contact
 type Contact struct {
 	ID        string   `json:"id"`
@@ -162,6 +405,17 @@ type Task struct {
 	Priority   string `json:"priority"`
 }
 
+// TaskDetail is a single task with the fields omitted from task listings
+type TaskDetail struct {
+	Task
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	ContactID   string `json:"contact_id,omitempty"`
+	ContactName string `json:"contact_name,omitempty"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
 // Event represents a Follow Up Boss event/appointment
 type Event struct {
 	ID        string   `json:"id"`
@@ -172,19 +426,142 @@ type Event struct {
 	Attendees []string `json:"attendees,omitempty"`
 }
 
+// Appointment is a Follow Up Boss appointment (e.g. a showing) linked to a contact
+type Appointment struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Start     string   `json:"start"`
+	End       string   `json:"end"`
+	Location  string   `json:"location,omitempty"`
+	ContactID string   `json:"contact_id"`
+	Attendees []string `json:"attendees,omitempty"`
+}
+
+// Note is a note logged against a Follow Up Boss contact
+type Note struct {
+	ID        string `json:"id"`
+	Subject   string `json:"subject,omitempty"`
+	Body      string `json:"body"`
+	CreatedBy string `json:"created_by,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ActionPlan represents a Follow Up Boss action plan (automated drip sequence)
+type ActionPlan struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// Webhook represents a Follow Up Boss webhook subscription
+type Webhook struct {
+	ID     int    `json:"id"`
+	Event  string `json:"event"`
+	URL    string `json:"url"`
+	Status string `json:"status"`
+}
+
+// sortFields lists the documented sort fields of each list endpoint
+var sortFields = map[string][]string{
+	"contacts": {"created", "updated", "name", "lastActivity", "stage"},
+	"leads":    {"created", "updated", "price", "stage"},
+	"tasks":    {"created", "updated", "dueDate"},
+	"events":   {"created", "updated", "start"},
+}
+
+// addSortFlags registers --sort and --order on a list command
+func addSortFlags(cmd *cobra.Command, resource string, sort, order *string) {
+	cmd.Flags().StringVar(sort, "sort", "", "Sort field: "+strings.Join(sortFields[resource], ", "))
+	cmd.Flags().StringVar(order, "order", "", "Sort direction with --sort: asc or desc")
+}
+
+// sortQuery validates --sort and --order for resource and returns the query
+// string fragment to send, or "" when no sort was requested
+func sortQuery(resource, sort, order string) (string, error) {
+	if sort == "" {
+		if order != "" {
+			return "", output.PrintError("invalid_input", "--order requires --sort", nil)
+		}
+		return "", nil
+	}
+
+	valid := false
+	for _, f := range sortFields[resource] {
+		if f == sort {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return "", output.PrintError("invalid_input", "Unsupported sort field: "+sort,
+			map[string]any{"supported": sortFields[resource]})
+	}
+
+	params := url.Values{}
+	params.Set("sort", sort)
+	if order != "" {
+		order = strings.ToLower(order)
+		if order != "asc" && order != "desc" {
+			return "", output.PrintError("invalid_input", "Unsupported order: "+order,
+				map[string]any{"supported": []string{"asc", "desc"}})
+		}
+		params.Set("sortDir", order)
+	}
+	return params.Encode(), nil
+}
+
 func newContactsCmd() *cobra.Command {
 	var limit int
 	var status string
 	var search string
+	var sort, order string
+	var all bool
+	var rateLimitBuffer int
 
 	cmd := &cobra.Command{
 		Use:   "contacts",
 		Short: "List contacts",
+		Long: `List contacts. With --all, follow pagination until every matching contact
+is fetched, ignoring --limit. Between pages the pull watches FUB's
+X-RateLimit-Remaining header and pauses for the rate-limit window once no
+more than --rate-limit-buffer requests remain, and honors Retry-After when
+throttled anyway.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			sortParams, err := sortQuery("contacts", sort, order)
+			if err != nil {
+				return err
+			}
+
 			client, err := newFUBClient()
 			if err != nil {
 				return err
 			}
+			client.rateLimitBuffer = rateLimitBuffer
+
+			if all {
+				params := url.Values{}
+				if status != "" {
+					params.Set("status", status)
+				}
+				if search != "" {
+					params.Set("q", search)
+				}
+				sortValues, _ := url.ParseQuery(sortParams)
+				for k, v := range sortValues {
+					params[k] = v
+				}
+
+				contacts, err := client.fetchAllContacts(params)
+				if err != nil {
+					return output.PrintError("request_failed", err.Error(), nil)
+				}
+				return output.Print(map[string]any{
+					"count":             len(contacts),
+					"total":             len(contacts),
+					"contacts":          contacts,
+					"rate_limit_pauses": client.pauses,
+				})
+			}
 
 			endpoint := "/contacts"
 			queryParams := ""
@@ -206,6 +583,12 @@ func newContactsCmd() *cobra.Command {
 				}
 				queryParams += "q=" + search
 			}
+			if sortParams != "" {
+				if queryParams != "" {
+					queryParams += "&"
+				}
+				queryParams += sortParams
+			}
 			if queryParams != "" {
 				endpoint += "?" + queryParams
 			}
@@ -235,22 +618,45 @@ func newContactsCmd() *cobra.Command {
 	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of results")
 	cmd.Flags().StringVarP(&status, "status", "s", "", "Filter by status")
 	cmd.Flags().StringVarP(&search, "search", "q", "", "Search query")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch every matching contact, following pagination")
+	cmd.Flags().IntVar(&rateLimitBuffer, "rate-limit-buffer", 5, "With --all, pause when this many requests or fewer remain in the rate-limit window")
+	addSortFlags(cmd, "contacts", &sort, &order)
 
 	return cmd
 }
 
+// contactExpansions maps each --expand name to the endpoint listing that
+// resource for one person, and the key holding the list in its response
+var contactExpansions = map[string]struct{ endpoint, key string }{
+	"deals": {"/opportunities", "opportunities"},
+	"tasks": {"/tasks", "tasks"},
+	"notes": {"/notes", "notes"},
+}
+
+// expandNotesLimit caps the recent notes embedded by --expand notes
+const expandNotesLimit = 10
+
 func newContactCmd() *cobra.Command {
+	var expand []string
+
 	cmd := &cobra.Command{
 		Use:   "contact [id]",
 		Short: "Get contact details",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, e := range expand {
+				if _, ok := contactExpansions[e]; !ok {
+					return output.PrintError("invalid_input", "Unknown expansion: "+e,
+						map[string]string{"supported": "deals, tasks, notes"})
+				}
+			}
+
 			client, err := newFUBClient()
 			if err != nil {
 				return err
 			}
 
-			body, err := client.doRequest("GET", "/contacts/"+args[0], nil)
+			body, err := client.doRequest("GET", contactPath(args[0]), nil)
 			if err != nil {
 				return output.PrintError("request_failed", err.Error(), nil)
 			}
@@ -260,49 +666,259 @@ func newContactCmd() *cobra.Command {
 				return output.PrintError("parse_error", err.Error(), nil)
 			}
 
-			return output.Print(contact)
+			if len(expand) == 0 {
+				return output.Print(contact)
+			}
+
+			expanded, err := client.fetchContactExpansions(args[0], expand)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			response := map[string]any{"contact": contact}
+			for k, v := range expanded {
+				response[k] = v
+			}
+
+			return output.Print(response)
 		},
 	}
 
+	cmd.Flags().StringSliceVar(&expand, "expand", nil, "Embed related data: deals, tasks, notes")
+
 	return cmd
 }
 
-func newLeadsCmd() *cobra.Command {
+// fetchContactExpansions concurrently fetches the requested resources for
+// one person, keyed by expansion name.
+func (c *fubClient) fetchContactExpansions(personID string, expand []string) (map[string]any, error) {
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]any)
+		errs    []string
+	)
+
+	for _, name := range expand {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+
+			var data any
+			params := url.Values{"personId": {personID}}
+			switch name {
+			case "deals":
+				data = &[]Lead{}
+			case "tasks":
+				data = &[]Task{}
+			case "notes":
+				data = &[]Note{}
+				params.Set("sort", "-created")
+				params.Set("limit", fmt.Sprint(expandNotesLimit))
+			}
+
+			exp := contactExpansions[name]
+			body, err := c.doRequest("GET", exp.endpoint+"?"+params.Encode(), nil)
+			if err == nil {
+				var raw map[string]json.RawMessage
+				if err = json.Unmarshal(body, &raw); err == nil {
+					if list, ok := raw[exp.key]; ok {
+						err = json.Unmarshal(list, data)
+					}
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, name+": "+err.Error())
+				return
+			}
+			results[name] = data
+		}(name)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to expand %s", strings.Join(errs, "; "))
+	}
+
+	return results, nil
+}
+
+// noteTimeLayouts are the CreatedAt formats seen from the notes endpoint
+var noteTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// parseNoteTime parses a note's CreatedAt, reporting false when it is empty
+// or in an unknown format
+func parseNoteTime(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range noteTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// sortNotes orders notes chronologically by CreatedAt, newest first unless
+// order is "asc". Notes whose timestamp cannot be parsed go last either way.
+func sortNotes(notes []Note, order string) {
+	sort.SliceStable(notes, func(i, j int) bool {
+		ti, okI := parseNoteTime(notes[i].CreatedAt)
+		tj, okJ := parseNoteTime(notes[j].CreatedAt)
+		if !okI || !okJ {
+			return okI && !okJ
+		}
+		if order == "asc" {
+			return ti.Before(tj)
+		}
+		return ti.After(tj)
+	})
+}
+
+func newNotesCmd() *cobra.Command {
 	var limit int
-	var status string
+	var all bool
+	var order string
+	var rateLimitBuffer int
 
 	cmd := &cobra.Command{
-		Use:   "leads",
-		Short: "List leads/opportunities",
+		Use:   "notes [contact-id]",
+		Short: "List a contact's notes in chronological order",
+		Long: `List the notes logged against a contact, newest first. Use --order asc for
+oldest first. With --all, follow pagination until every note is fetched,
+ignoring --limit and pacing requests like contacts --all. Notes are sorted
+by their parsed created time, so the order holds even when the API returns
+them unordered.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			order = strings.ToLower(order)
+			if order != "asc" && order != "desc" {
+				return output.PrintError("invalid_input", "Unsupported order: "+order,
+					map[string]any{"supported": []string{"asc", "desc"}})
+			}
+			apiSort := "-created"
+			if order == "asc" {
+				apiSort = "created"
+			}
+
 			client, err := newFUBClient()
 			if err != nil {
 				return err
 			}
+			client.rateLimitBuffer = rateLimitBuffer
+
+			var notes []Note
+			total := 0
+			if all {
+				notes, err = client.fetchAllNotes(args[0], apiSort)
+				if err != nil {
+					return output.PrintError("request_failed", err.Error(), nil)
+				}
+				total = len(notes)
+			} else {
+				params := url.Values{}
+				params.Set("personId", args[0])
+				params.Set("sort", apiSort)
+				if limit > 0 {
+					params.Set("limit", fmt.Sprint(limit))
+				}
 
-			endpoint := "/opportunities"
-			queryParams := ""
-			if limit > 0 {
-				queryParams = "limit=" + fmt.Sprint(limit)
+				body, err := client.doRequest("GET", "/notes?"+params.Encode(), nil)
+				if err != nil {
+					return output.PrintError("request_failed", err.Error(), nil)
+				}
+
+				var result struct {
+					Notes []Note `json:"notes"`
+					Total int    `json:"total"`
+				}
+				if err := json.Unmarshal(body, &result); err != nil {
+					return output.PrintError("parse_error", err.Error(), nil)
+				}
+				notes, total = result.Notes, result.Total
 			}
-			if status != "" {
-				if queryParams != "" {
-					queryParams += "&"
+
+			if notes == nil {
+				notes = []Note{}
+			}
+			sortNotes(notes, order)
+
+			response := map[string]any{
+				"contact_id": args[0],
+				"order":      order,
+				"count":      len(notes),
+				"total":      total,
+				"notes":      notes,
+			}
+			if all {
+				response["rate_limit_pauses"] = client.pauses
+			}
+			return output.Print(response)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of notes")
+	cmd.Flags().BoolVar(&all, "all", false, "Fetch every note, following pagination")
+	cmd.Flags().StringVar(&order, "order", "desc", "Chronological order: desc (newest first) or asc")
+	cmd.Flags().IntVar(&rateLimitBuffer, "rate-limit-buffer", 5, "With --all, pause when this many requests or fewer remain in the rate-limit window")
+
+	return cmd
+}
+
+func newSearchCmd() *cobra.Command {
+	var name string
+	var email string
+	var phone string
+	var tag string
+	var stage string
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Search contacts by several fields at once",
+		Long: `Search contacts matching ALL of the given fields, combined into a single
+request. Unlike contacts --search, each value is sent as its own query parameter.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			params := url.Values{}
+			for key, val := range map[string]string{
+				"name":  name,
+				"email": email,
+				"phone": phone,
+				"tags":  tag,
+				"stage": stage,
+			} {
+				if val != "" {
+					params.Set(key, val)
 				}
-				queryParams += "status=" + status
 			}
-			if queryParams != "" {
-				endpoint += "?" + queryParams
+			if len(params) == 0 {
+				return output.PrintError("invalid_input",
+					"Provide at least one of --name, --email, --phone, --tag, --stage", nil)
+			}
+			if limit > 0 {
+				params.Set("limit", fmt.Sprint(limit))
 			}
 
-			body, err := client.doRequest("GET", endpoint, nil)
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			body, err := client.doRequest("GET", "/contacts?"+params.Encode(), nil)
 			if err != nil {
 				return output.PrintError("request_failed", err.Error(), nil)
 			}
 
 			var result struct {
-				Opportunities []Lead `json:"opportunities"`
-				Total         int    `json:"total"`
+				Contacts []Contact `json:"contacts"`
+				Total    int       `json:"total"`
 			}
 
 			if err := json.Unmarshal(body, &result); err != nil {
@@ -310,42 +926,65 @@ func newLeadsCmd() *cobra.Command {
 			}
 
 			return output.Print(map[string]any{
-				"count": len(result.Opportunities),
-				"total": result.Total,
-				"leads": result.Opportunities,
+				"count":    len(result.Contacts),
+				"total":    result.Total,
+				"contacts": result.Contacts,
 			})
 		},
 	}
 
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Match on name")
+	cmd.Flags().StringVarP(&email, "email", "e", "", "Match on email address")
+	cmd.Flags().StringVarP(&phone, "phone", "p", "", "Match on phone number")
+	cmd.Flags().StringVarP(&tag, "tag", "t", "", "Match contacts carrying this tag")
+	cmd.Flags().StringVarP(&stage, "stage", "s", "", "Match on stage")
 	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of results")
-	cmd.Flags().StringVarP(&status, "status", "s", "", "Filter by status")
 
 	return cmd
 }
 
-func newTasksCmd() *cobra.Command {
+func newLeadsCmd() *cobra.Command {
 	var limit int
-	var completed string
+	var status string
+	var assignedTo string
+	var sort, order string
 
 	cmd := &cobra.Command{
-		Use:   "tasks",
-		Short: "List tasks/reminders",
+		Use:   "leads",
+		Short: "List leads/opportunities",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			sortParams, err := sortQuery("leads", sort, order)
+			if err != nil {
+				return err
+			}
+
 			client, err := newFUBClient()
 			if err != nil {
 				return err
 			}
 
-			endpoint := "/tasks"
+			endpoint := "/opportunities"
 			queryParams := ""
 			if limit > 0 {
 				queryParams = "limit=" + fmt.Sprint(limit)
 			}
-			if completed != "" {
+			if status != "" {
 				if queryParams != "" {
 					queryParams += "&"
 				}
-				queryParams += "completed=" + completed
+				queryParams += "status=" + status
+			}
+			if assignedTo != "" {
+				if queryParams != "" {
+					queryParams += "&"
+				}
+				queryParams += "assignedUserId=" + url.QueryEscape(assignedTo)
+			}
+			if sortParams != "" {
+				if queryParams != "" {
+					queryParams += "&"
+				}
+				queryParams += sortParams
 			}
 			if queryParams != "" {
 				endpoint += "?" + queryParams
@@ -357,8 +996,8 @@ func newTasksCmd() *cobra.Command {
 			}
 
 			var result struct {
-				Tasks []Task `json:"tasks"`
-				Total int    `json:"total"`
+				Opportunities []Lead `json:"opportunities"`
+				Total         int    `json:"total"`
 			}
 
 			if err := json.Unmarshal(body, &result); err != nil {
@@ -366,49 +1005,267 @@ func newTasksCmd() *cobra.Command {
 			}
 
 			return output.Print(map[string]any{
-				"count": len(result.Tasks),
+				"count": len(result.Opportunities),
 				"total": result.Total,
-				"tasks": result.Tasks,
+				"leads": result.Opportunities,
 			})
 		},
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of results")
-	cmd.Flags().StringVarP(&completed, "completed", "c", "", "Filter by completed (true/false)")
+	cmd.Flags().StringVarP(&status, "status", "s", "", "Filter by status")
+	cmd.Flags().StringVar(&assignedTo, "assigned-to", "", "Only items assigned to this user ID")
+	addSortFlags(cmd, "leads", &sort, &order)
 
 	return cmd
 }
 
-func newEventsCmd() *cobra.Command {
-	var limit int
-	var startDate string
-	var endDate string
+func newCreateLeadCmd() *cobra.Command {
+	var contactID string
+	var stage string
+	var price int64
+	var address string
 
 	cmd := &cobra.Command{
-		Use:   "events",
-		Short: "List events/appointments",
+		Use:   "create-lead",
+		Short: "Create a lead/opportunity for an existing contact",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client, err := newFUBClient()
 			if err != nil {
 				return err
 			}
 
-			endpoint := "/events"
+			// Check the contact first so a bad ID reports clearly instead of
+			// surfacing as a generic validation error from the create call
+			if _, err := client.getContact(contactID); err != nil {
+				var apiErr *apiError
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+					return output.PrintError("not_found", "Contact not found: "+contactID, nil)
+				}
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			reqBody := map[string]any{
+				"contact_id": contactID,
+			}
+			if stage != "" {
+				reqBody["stage"] = stage
+			}
+			if price > 0 {
+				reqBody["price"] = price
+			}
+			if address != "" {
+				reqBody["address"] = address
+			}
+
+			body, err := client.doRequest("POST", "/opportunities", reqBody)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			var lead Lead
+			if err := json.Unmarshal(body, &lead); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(lead)
+		},
+	}
+
+	cmd.Flags().StringVar(&contactID, "contact", "", "Contact ID to link the lead to (required)")
+	cmd.Flags().StringVarP(&stage, "stage", "s", "", "Pipeline stage")
+	cmd.Flags().Int64VarP(&price, "price", "p", 0, "Expected price")
+	cmd.Flags().StringVarP(&address, "address", "a", "", "Property address")
+	_ = cmd.MarkFlagRequired("contact")
+
+	return cmd
+}
+
+func newCreateAppointmentCmd() *cobra.Command {
+	var title string
+	var start string
+	var end string
+	var contactID string
+	var attendees []string
+	var location string
+
+	cmd := &cobra.Command{
+		Use:   "create-appointment",
+		Short: "Create an appointment or showing linked to a contact",
+		Long: `Create an appointment linked to a contact, inviting attendees by email.
+
+Times accept RFC 3339 or common forms such as "2024-06-01 14:00",
+"2024-06-01 2:00pm" or "Jun 1 2024 2pm", read in the local timezone when no
+offset is given. --end defaults to one hour after --start.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			startTime, err := parseAppointmentTime(start)
+			if err != nil {
+				return output.PrintError("invalid_input", err.Error(), nil)
+			}
+			endTime := startTime.Add(time.Hour)
+			if end != "" {
+				if endTime, err = parseAppointmentTime(end); err != nil {
+					return output.PrintError("invalid_input", err.Error(), nil)
+				}
+			}
+			if !endTime.After(startTime) {
+				return output.PrintError("invalid_input", "--end must be after --start", nil)
+			}
+			for _, a := range attendees {
+				if !strings.Contains(a, "@") {
+					return output.PrintError("invalid_input", "Attendee is not an email address: "+a, nil)
+				}
+			}
+
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.getContact(contactID); err != nil {
+				var apiErr *apiError
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+					return output.PrintError("not_found", "Contact not found: "+contactID, nil)
+				}
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			// FUB models everyone on an appointment as an invitee; the
+			// contact is linked by personId and others by email
+			invitees := []map[string]any{{"personId": contactID}}
+			for _, a := range attendees {
+				invitees = append(invitees, map[string]any{"email": strings.TrimSpace(a)})
+			}
+			reqBody := map[string]any{
+				"title":    title,
+				"start":    startTime.Format(time.RFC3339),
+				"end":      endTime.Format(time.RFC3339),
+				"invitees": invitees,
+			}
+			if location != "" {
+				reqBody["location"] = location
+			}
+
+			body, err := client.doRequest("POST", "/appointments", reqBody)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			var created struct {
+				ID       json.Number `json:"id"`
+				Title    string      `json:"title"`
+				Start    string      `json:"start"`
+				End      string      `json:"end"`
+				Location string      `json:"location"`
+			}
+			if err := json.Unmarshal(body, &created); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(Appointment{
+				ID:        created.ID.String(),
+				Title:     created.Title,
+				Start:     created.Start,
+				End:       created.End,
+				Location:  created.Location,
+				ContactID: contactID,
+				Attendees: attendees,
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&title, "title", "t", "", "Appointment title (required)")
+	cmd.Flags().StringVarP(&start, "start", "s", "", "Start time (required)")
+	cmd.Flags().StringVarP(&end, "end", "e", "", "End time (default: start + 1h)")
+	cmd.Flags().StringVar(&contactID, "contact", "", "Contact ID the appointment is for (required)")
+	cmd.Flags().StringSliceVar(&attendees, "attendees", nil, "Attendee email addresses")
+	cmd.Flags().StringVarP(&location, "location", "l", "", "Appointment location, e.g. the property address")
+	_ = cmd.MarkFlagRequired("title")
+	_ = cmd.MarkFlagRequired("start")
+	_ = cmd.MarkFlagRequired("contact")
+
+	return cmd
+}
+
+// appointmentLayouts are the forms accepted for appointment times, tried in order
+var appointmentLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02 3:04pm",
+	"2006-01-02 3pm",
+	"Jan 2 2006 15:04",
+	"Jan 2 2006 3:04pm",
+	"Jan 2 2006 3pm",
+	"01/02/2006 15:04",
+	"01/02/2006 3:04pm",
+	"01/02/2006 3pm",
+}
+
+// parseAppointmentTime parses s leniently, ignoring case and commas and
+// reading times without an offset in the local timezone
+func parseAppointmentTime(s string) (time.Time, error) {
+	v := strings.Join(strings.Fields(strings.ReplaceAll(s, ",", " ")), " ")
+	lower := strings.ToLower(v)
+	lower = strings.Replace(strings.Replace(lower, " am", "am", 1), " pm", "pm", 1)
+	// Month names are matched case-sensitively, so also try "Jun ..."
+	title := lower
+	if title != "" {
+		title = strings.ToUpper(title[:1]) + title[1:]
+	}
+
+	for _, layout := range appointmentLayouts {
+		for _, candidate := range []string{v, lower, title} {
+			if t, err := time.ParseInLocation(layout, candidate, time.Local); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q, try \"2024-06-01 14:00\"", s)
+}
+
+func newTasksCmd() *cobra.Command {
+	var limit int
+	var completed string
+	var assignedTo string
+	var sort, order string
+
+	cmd := &cobra.Command{
+		Use:   "tasks",
+		Short: "List tasks/reminders",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sortParams, err := sortQuery("tasks", sort, order)
+			if err != nil {
+				return err
+			}
+
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			endpoint := "/tasks"
 			queryParams := ""
 			if limit > 0 {
 				queryParams = "limit=" + fmt.Sprint(limit)
 			}
-			if startDate != "" {
+			if completed != "" {
 				if queryParams != "" {
 					queryParams += "&"
 				}
-				queryParams += "start_date=" + startDate
+				queryParams += "completed=" + completed
 			}
-			if endDate != "" {
+			if assignedTo != "" {
+				if queryParams != "" {
+					queryParams += "&"
+				}
+				queryParams += "assignedUserId=" + url.QueryEscape(assignedTo)
+			}
+			if sortParams != "" {
 				if queryParams != "" {
 					queryParams += "&"
 				}
-				queryParams += "end_date=" + endDate
+				queryParams += sortParams
 			}
 			if queryParams != "" {
 				endpoint += "?" + queryParams
@@ -419,6 +1276,109 @@ func newEventsCmd() *cobra.Command {
 				return output.PrintError("request_failed", err.Error(), nil)
 			}
 
+			var result struct {
+				Tasks []Task `json:"tasks"`
+				Total int    `json:"total"`
+			}
+
+			if err := json.Unmarshal(body, &result); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(map[string]any{
+				"count": len(result.Tasks),
+				"total": result.Total,
+				"tasks": result.Tasks,
+			})
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of results")
+	cmd.Flags().StringVarP(&completed, "completed", "c", "", "Filter by completed (true/false)")
+	cmd.Flags().StringVar(&assignedTo, "assigned-to", "", "Only items assigned to this user ID")
+	addSortFlags(cmd, "tasks", &sort, &order)
+
+	return cmd
+}
+
+func newTaskCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "task [id]",
+		Aliases: []string{"get-task"},
+		Short:   "Get task details",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			body, err := client.doRequest("GET", "/tasks/"+args[0], nil)
+			if err != nil {
+				var apiErr *apiError
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+					return output.PrintError("task_not_found", "Task not found: "+args[0], nil)
+				}
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			var task TaskDetail
+			if err := json.Unmarshal(body, &task); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(task)
+		},
+	}
+
+	return cmd
+}
+
+func newEventsCmd() *cobra.Command {
+	var limit int
+	var startDate string
+	var endDate string
+	var contactID string
+	var sort, order string
+
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "List events/appointments",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sortParams, err := sortQuery("events", sort, order)
+			if err != nil {
+				return err
+			}
+
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			params, _ := url.ParseQuery(sortParams)
+			if limit > 0 {
+				params.Set("limit", fmt.Sprint(limit))
+			}
+			if startDate != "" {
+				params.Set("start_date", startDate)
+			}
+			if endDate != "" {
+				params.Set("end_date", endDate)
+			}
+			if contactID != "" {
+				params.Set("personId", contactID)
+			}
+
+			endpoint := "/events"
+			if len(params) > 0 {
+				endpoint += "?" + params.Encode()
+			}
+
+			body, err := client.doRequest("GET", endpoint, nil)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
 			var result struct {
 				Events []Event `json:"events"`
 				Total  int     `json:"total"`
@@ -439,6 +1399,714 @@ func newEventsCmd() *cobra.Command {
 	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of results")
 	cmd.Flags().StringVarP(&startDate, "start", "s", "", "Start date (YYYY-MM-DD)")
 	cmd.Flags().StringVarP(&endDate, "end", "e", "", "End date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&contactID, "contact", "", "Only events for this contact ID")
+	addSortFlags(cmd, "events", &sort, &order)
+
+	return cmd
+}
+
+func newActionPlansCmd() *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "action-plans",
+		Short: "List action plans",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			endpoint := "/actionPlans"
+			if limit > 0 {
+				endpoint += "?limit=" + fmt.Sprint(limit)
+			}
+
+			body, err := client.doRequest("GET", endpoint, nil)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			var result struct {
+				ActionPlans []ActionPlan `json:"actionPlans"`
+			}
+
+			if err := json.Unmarshal(body, &result); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(map[string]any{
+				"count":        len(result.ActionPlans),
+				"action_plans": result.ActionPlans,
+			})
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "l", 20, "Number of results")
+
+	return cmd
+}
+
+func newAssignPlanCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "assign-plan [contact-id] [plan-id]",
+		Short: "Start an action plan on a contact",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var personID, planID int
+			if _, err := fmt.Sscanf(args[0], "%d", &personID); err != nil {
+				return output.PrintError("invalid_input", "Contact ID must be numeric: "+args[0], nil)
+			}
+			if _, err := fmt.Sscanf(args[1], "%d", &planID); err != nil {
+				return output.PrintError("invalid_input", "Plan ID must be numeric: "+args[1], nil)
+			}
+
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			reqBody := map[string]any{
+				"personId":     personID,
+				"actionPlanId": planID,
+			}
+
+			body, err := client.doRequest("POST", "/actionPlansPeople", reqBody)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			var result map[string]any
+			if err := json.Unmarshal(body, &result); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(map[string]any{
+				"contact_id":     args[0],
+				"action_plan_id": args[1],
+				"assignment":     result,
+			})
+		},
+	}
+
+	return cmd
+}
+
+func newBulkTagCmd() *cobra.Command {
+	var tag string
+	var status string
+	var search string
+	var concurrency int
+	var rateLimitBuffer int
+
+	cmd := &cobra.Command{
+		Use:   "bulk-tag",
+		Short: "Apply a tag to every contact matching a filter",
+		Long: `Find all contacts matching --status and/or --search (following pagination)
+and add --tag to each one. Requests run with bounded concurrency and transient
+failures are retried. Results are reported per contact.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if status == "" && search == "" {
+				return output.PrintError("invalid_input", "Provide --status and/or --search to select contacts", nil)
+			}
+			if concurrency < 1 {
+				concurrency = 1
+			}
+
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+			client.rateLimitBuffer = rateLimitBuffer
+
+			params := url.Values{}
+			if status != "" {
+				params.Set("status", status)
+			}
+			if search != "" {
+				params.Set("q", search)
+			}
+
+			contacts, err := client.fetchAllContacts(params)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			results := make([]map[string]any, len(contacts))
+			sem := make(chan struct{}, concurrency)
+			var wg sync.WaitGroup
+
+			for i, contact := range contacts {
+				wg.Add(1)
+				go func(i int, contact Contact) {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+
+					result := map[string]any{"id": contact.ID, "name": contact.Name}
+					if hasTag(contact.Tags, tag) {
+						result["status"] = "skipped"
+						result["reason"] = "already tagged"
+						results[i] = result
+						return
+					}
+
+					tags := append(append([]string{}, contact.Tags...), tag)
+					_, err := client.doRequestWithRetry("PUT", contactPath(contact.ID), map[string]any{"tags": tags})
+					if err != nil {
+						result["status"] = "failed"
+						result["error"] = err.Error()
+					} else {
+						result["status"] = "tagged"
+					}
+					results[i] = result
+				}(i, contact)
+			}
+			wg.Wait()
+
+			counts := map[string]int{"tagged": 0, "skipped": 0, "failed": 0}
+			for _, r := range results {
+				counts[r["status"].(string)]++
+			}
+
+			return output.Print(map[string]any{
+				"tag":     tag,
+				"matched": len(contacts),
+				"tagged":  counts["tagged"],
+				"skipped": counts["skipped"],
+				"failed":  counts["failed"],
+				"results": results,
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&tag, "tag", "t", "", "Tag to apply (required)")
+	cmd.Flags().StringVarP(&status, "status", "s", "", "Filter by status")
+	cmd.Flags().StringVarP(&search, "search", "q", "", "Search query")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Maximum concurrent update requests")
+	cmd.Flags().IntVar(&rateLimitBuffer, "rate-limit-buffer", 5, "Pause paging when this many requests or fewer remain in the rate-limit window")
+	_ = cmd.MarkFlagRequired("tag")
+
+	return cmd
+}
+
+// hasTag reports whether tags already contains tag (case-insensitive)
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func newDeleteContactCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "delete-contact [id]",
+		Short: "Permanently delete a contact",
+		Long: `Permanently delete a contact, e.g. for a right-to-be-forgotten request.
+This cannot be undone, so --force is required to confirm.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			if !force {
+				return output.PrintError("confirmation_required",
+					"Deleting a contact cannot be undone; pass --force to confirm",
+					map[string]string{"id": id})
+			}
+
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.doRequest("DELETE", contactPath(id), nil); err != nil {
+				var apiErr *apiError
+				if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+					return output.PrintError("contact_not_found", "Contact not found: "+id,
+						map[string]string{"id": id})
+				}
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			return output.Print(map[string]any{
+				"id":      id,
+				"deleted": true,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Confirm the permanent deletion")
+
+	return cmd
+}
+
+func newMergeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "merge [keep-id] [merge-id]",
+		Aliases: []string{"merge-contacts"},
+		Short:   "Merge a duplicate contact into another",
+		Long: `Merge the contact [merge-id] into [keep-id]. Fields missing on the surviving
+contact (email, phone, source) are copied over and tags are unioned, then the
+duplicate is deleted. Returns the surviving contact.
+
+The merge is not atomic: the update and the delete are separate requests,
+each retried on rate limits and server errors. If the delete still fails,
+the fields have already been merged and both contacts remain; the error
+names the duplicate so it can be deleted with delete-contact.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keepID, mergeID := args[0], args[1]
+			if keepID == mergeID {
+				return output.PrintError("invalid_input", "Cannot merge a contact into itself", nil)
+			}
+
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			keep, err := client.getContact(keepID)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), map[string]string{"id": keepID})
+			}
+			dup, err := client.getContact(mergeID)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), map[string]string{"id": mergeID})
+			}
+
+			update := mergeContactFields(keep, dup)
+			if len(update) > 0 {
+				if _, err := client.doRequestWithRetry("PUT", contactPath(keepID), update); err != nil {
+					return output.PrintError("merge_failed", err.Error(), nil)
+				}
+			}
+
+			if _, err := client.doRequestWithRetry("DELETE", contactPath(mergeID), nil); err != nil {
+				return output.PrintError("delete_failed",
+					"Fields were merged but the duplicate could not be deleted: "+err.Error(),
+					map[string]string{"merge_id": mergeID})
+			}
+
+			survivor, err := client.getContact(keepID)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			return output.Print(map[string]any{
+				"merged_id":      mergeID,
+				"updated_fields": update,
+				"contact":        survivor,
+			})
+		},
+	}
+
+	return cmd
+}
+
+// getContact fetches a single contact by ID
+func (c *fubClient) getContact(id string) (Contact, error) {
+	var contact Contact
+	body, err := c.doRequest("GET", contactPath(id), nil)
+	if err != nil {
+		return contact, err
+	}
+	err = json.Unmarshal(body, &contact)
+	return contact, err
+}
+
+// mergeContactFields returns the update needed to fold dup into keep:
+// empty fields on keep are filled from dup and tags are unioned.
+func mergeContactFields(keep, dup Contact) map[string]any {
+	update := make(map[string]any)
+	if keep.Email == "" && dup.Email != "" {
+		update["email"] = dup.Email
+	}
+	if keep.Phone == "" && dup.Phone != "" {
+		update["phone"] = dup.Phone
+	}
+	if keep.Source == "" && dup.Source != "" {
+		update["source"] = dup.Source
+	}
+
+	tags := append([]string{}, keep.Tags...)
+	for _, t := range dup.Tags {
+		if !hasTag(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+	if len(tags) > len(keep.Tags) {
+		update["tags"] = tags
+	}
+
+	return update
+}
+
+func newWebhooksCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "webhooks",
+		Short: "List registered webhooks",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			body, err := client.doRequest("GET", "/webhooks", nil)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			var result struct {
+				Webhooks []Webhook `json:"webhooks"`
+			}
+
+			if err := json.Unmarshal(body, &result); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(map[string]any{
+				"count":    len(result.Webhooks),
+				"webhooks": result.Webhooks,
+			})
+		},
+	}
+
+	return cmd
+}
+
+func newRegisterWebhookCmd() *cobra.Command {
+	var event string
+	var hookURL string
+
+	cmd := &cobra.Command{
+		Use:   "register-webhook",
+		Short: "Register a webhook for a FUB event (e.g. peopleCreated)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if u, err := url.Parse(hookURL); err != nil || u.Scheme != "https" || u.Host == "" {
+				return output.PrintError("invalid_input", "Webhook URL must be an absolute https:// URL", nil)
+			}
+
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			reqBody := map[string]string{
+				"event": event,
+				"url":   hookURL,
+			}
+
+			body, err := client.doRequest("POST", "/webhooks", reqBody)
+			if err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			var webhook Webhook
+			if err := json.Unmarshal(body, &webhook); err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			return output.Print(webhook)
+		},
+	}
+
+	cmd.Flags().StringVarP(&event, "event", "e", "", "Event name, e.g. peopleCreated, peopleUpdated (required)")
+	cmd.Flags().StringVarP(&hookURL, "url", "u", "", "HTTPS callback URL (required)")
+	_ = cmd.MarkFlagRequired("event")
+	_ = cmd.MarkFlagRequired("url")
+
+	return cmd
+}
+
+func newDeleteWebhookCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete-webhook [id]",
+		Short: "Delete a registered webhook",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.doRequest("DELETE", "/webhooks/"+args[0], nil); err != nil {
+				return output.PrintError("request_failed", err.Error(), nil)
+			}
+
+			return output.Print(map[string]any{
+				"id":      args[0],
+				"deleted": true,
+			})
+		},
+	}
+
+	return cmd
+}
+
+// importRow is a contact parsed from one CSV data row
+type importRow struct {
+	Line  int
+	Name  string
+	Email string
+	Phone string
+}
+
+func newImportCmd() *cobra.Command {
+	var file string
+	var source string
+	var tags []string
+	var allowDuplicates bool
+	var concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Create contacts in bulk from a CSV file",
+		Long: `Create one contact per row of a CSV file with a header row naming the
+name, email, and phone columns (first_name/last_name are joined when there is
+no name column). Phone numbers are normalized to E.164, requests run with
+bounded concurrency and rate-limited (429) creates are retried; other
+failures are not, since FUB may already have created the contact. Rows whose email
+already exists in Follow Up Boss, or earlier in the file, are skipped unless
+--allow-duplicates is set. Results are reported per row.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if concurrency < 1 {
+				concurrency = 1
+			}
+
+			f, err := os.Open(file)
+			if err != nil {
+				return output.PrintError("read_failed", err.Error(), nil)
+			}
+			defer f.Close()
+
+			rows, err := parseImportCSV(f)
+			if err != nil {
+				return output.PrintError("parse_error", err.Error(), nil)
+			}
+
+			client, err := newFUBClient()
+			if err != nil {
+				return err
+			}
+
+			results := make([]map[string]any, len(rows))
+			seen := make(map[string]bool)
+			var pending []int
+			for i, row := range rows {
+				result := map[string]any{"row": row.Line, "name": row.Name, "email": row.Email}
+				results[i] = result
+
+				if row.Name == "" && row.Email == "" && row.Phone == "" {
+					result["status"] = "failed"
+					result["error"] = "empty row"
+					continue
+				}
+				if row.Phone != "" {
+					phone, ok := normalizeE164(row.Phone)
+					if !ok {
+						result["status"] = "failed"
+						result["error"] = "invalid phone number: " + row.Phone
+						continue
+					}
+					rows[i].Phone = phone
+				}
+				key := strings.ToLower(row.Email)
+				if key != "" && !allowDuplicates {
+					if seen[key] {
+						result["status"] = "skipped"
+						result["reason"] = "duplicate email in file"
+						continue
+					}
+					seen[key] = true
+				}
+				pending = append(pending, i)
+			}
+
+			sem := make(chan struct{}, concurrency)
+			var wg sync.WaitGroup
+			for _, i := range pending {
+				wg.Add(1)
+				go func(row importRow, result map[string]any) {
+					defer wg.Done()
+					sem <- struct{}{}
+					defer func() { <-sem }()
+
+					if row.Email != "" && !allowDuplicates {
+						existing, err := client.findByEmail(row.Email)
+						if err != nil {
+							result["status"] = "failed"
+							result["error"] = err.Error()
+							return
+						}
+						if existing != nil {
+							result["status"] = "skipped"
+							result["reason"] = "email already exists"
+							result["id"] = existing.ID
+							return
+						}
+					}
+
+					reqBody := map[string]any{}
+					for key, val := range map[string]string{
+						"name":   row.Name,
+						"email":  row.Email,
+						"phone":  row.Phone,
+						"source": source,
+					} {
+						if val != "" {
+							reqBody[key] = val
+						}
+					}
+					if len(tags) > 0 {
+						reqBody["tags"] = tags
+					}
+
+					body, err := client.doCreateWithRetry("POST", "/contacts", reqBody)
+					if err != nil {
+						result["status"] = "failed"
+						result["error"] = err.Error()
+						return
+					}
+
+					var created Contact
+					if err := json.Unmarshal(body, &created); err != nil {
+						result["status"] = "failed"
+						result["error"] = err.Error()
+						return
+					}
+					result["status"] = "created"
+					result["id"] = created.ID
+				}(rows[i], results[i])
+			}
+			wg.Wait()
+
+			counts := map[string]int{"created": 0, "skipped": 0, "failed": 0}
+			for _, r := range results {
+				counts[r["status"].(string)]++
+			}
+
+			return output.Print(map[string]any{
+				"rows":    len(rows),
+				"created": counts["created"],
+				"skipped": counts["skipped"],
+				"failed":  counts["failed"],
+				"results": results,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "Path to the CSV file (required)")
+	cmd.Flags().StringVar(&source, "source", "", "Lead source to set on every contact")
+	cmd.Flags().StringSliceVarP(&tags, "tag", "t", nil, "Tag to apply to every contact (repeatable)")
+	cmd.Flags().BoolVar(&allowDuplicates, "allow-duplicates", false, "Create rows even when the email already exists")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Maximum concurrent create requests")
+	_ = cmd.MarkFlagRequired("file")
 
 	return cmd
 }
+
+// parseImportCSV reads contacts from CSV, locating columns by header name
+func parseImportCSV(r io.Reader) ([]importRow, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	cols := make(map[string]int)
+	for i, h := range header {
+		key := strings.ToLower(strings.TrimSpace(h))
+		key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+		cols[key] = i
+	}
+	_, hasName := cols["name"]
+	_, hasFirst := cols["first_name"]
+	if _, hasEmail := cols["email"]; !hasName && !hasFirst && !hasEmail {
+		return nil, errors.New("CSV header must include a name, first_name, or email column")
+	}
+
+	field := func(record []string, col string) string {
+		i, ok := cols[col]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var rows []importRow
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		name := field(record, "name")
+		if name == "" {
+			name = strings.TrimSpace(field(record, "first_name") + " " + field(record, "last_name"))
+		}
+		rows = append(rows, importRow{
+			Line:  line,
+			Name:  name,
+			Email: field(record, "email"),
+			Phone: field(record, "phone"),
+		})
+	}
+	return rows, nil
+}
+
+// normalizeE164 converts a phone number to E.164, assuming the US country
+// code for 10-digit numbers without one
+func normalizeE164(phone string) (string, bool) {
+	var digits strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+	d := digits.String()
+
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(phone), "+"):
+	case len(d) == 10:
+		d = "1" + d
+	case len(d) == 11 && d[0] == '1':
+	default:
+		return "", false
+	}
+	if len(d) < 8 || len(d) > 15 {
+		return "", false
+	}
+	return "+" + d, true
+}
+
+// findByEmail returns the first contact with the given email, or nil
+func (c *fubClient) findByEmail(email string) (*Contact, error) {
+	params := url.Values{}
+	params.Set("email", email)
+	params.Set("limit", "1")
+
+	body, err := c.doRequestWithRetry("GET", "/contacts?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Contacts []Contact `json:"contacts"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Contacts) == 0 {
+		return nil, nil
+	}
+	return &result.Contacts[0], nil
+}
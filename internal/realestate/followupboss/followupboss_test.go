@@ -0,0 +1,279 @@
+package followupboss
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/unstablemind/pocket/internal/common/config"
+)
+
+func TestNormalizeE164(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"(555) 123-4567", "+15551234567", true},
+		{"555.123.4567", "+15551234567", true},
+		{"1-555-123-4567", "+15551234567", true},
+		{"+44 20 7946 0958", "+442079460958", true},
+		{"+1 (555) 123-4567", "+15551234567", true},
+		{"123-4567", "", false},
+		{"2-555-123-4567", "", false},
+		{"+1234567", "", false},
+		{"+1234567890123456", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := normalizeE164(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("normalizeE164(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseImportCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		want    []importRow
+		wantErr bool
+	}{
+		{
+			name: "name column",
+			csv:  "name,email,phone\nJane Doe,jane@x.com,555-0100\n",
+			want: []importRow{{Line: 2, Name: "Jane Doe", Email: "jane@x.com", Phone: "555-0100"}},
+		},
+		{
+			name: "aliased first and last name headers",
+			csv:  "First Name,last-name, EMAIL \nJane,Doe,jane@x.com\nBob,,\n",
+			want: []importRow{
+				{Line: 2, Name: "Jane Doe", Email: "jane@x.com"},
+				{Line: 3, Name: "Bob"},
+			},
+		},
+		{
+			name: "short rows",
+			csv:  "email,phone\nonly@x.com\n",
+			want: []importRow{{Line: 2, Email: "only@x.com"}},
+		},
+		{
+			name:    "no identifying column",
+			csv:     "phone,company\n555-0100,Acme\n",
+			wantErr: true,
+		},
+		{
+			name:    "empty input",
+			csv:     "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportCSV(strings.NewReader(tt.csv))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("rows = %+v, want %+v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("row %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("7"); got != 7*time.Second {
+		t.Errorf("seconds = %v, want 7s", got)
+	}
+	for _, v := range []string{"", "0", "-3", "soon", "Mon, 01 Jan 2001 00:00:00 GMT"} {
+		if got := parseRetryAfter(v); got != 0 {
+			t.Errorf("parseRetryAfter(%q) = %v, want 0", v, got)
+		}
+	}
+	future := time.Now().Add(time.Minute).UTC().Format(time.RFC1123)
+	future = strings.Replace(future, "UTC", "GMT", 1)
+	if got := parseRetryAfter(future); got <= 0 || got > time.Minute {
+		t.Errorf("http date = %v, want within a minute", got)
+	}
+}
+
+func TestMergeContactFields(t *testing.T) {
+	keep := Contact{Email: "keep@x.com", Tags: []string{"buyer"}}
+	dup := Contact{Email: "dup@x.com", Phone: "555-0100", Source: "Zillow", Tags: []string{"buyer", "hot"}}
+
+	got := mergeContactFields(keep, dup)
+	if _, ok := got["email"]; ok {
+		t.Error("email on the surviving contact should not be overwritten")
+	}
+	if got["phone"] != "555-0100" || got["source"] != "Zillow" {
+		t.Errorf("update = %v", got)
+	}
+	if tags, _ := got["tags"].([]string); len(tags) != 2 || tags[1] != "hot" {
+		t.Errorf("tags = %v, want [buyer hot]", got["tags"])
+	}
+
+	if got := mergeContactFields(dup, keep); len(got) != 0 {
+		t.Errorf("nothing to fill: update = %v", got)
+	}
+}
+
+func TestParseAppointmentTime(t *testing.T) {
+	want := time.Date(2024, 6, 1, 14, 0, 0, 0, time.Local)
+	for _, in := range []string{"2024-06-01 14:00", "2024-06-01T14:00", "2024-06-01 2pm", "jun 1, 2024 2:00 PM", "06/01/2024 2:00pm"} {
+		got, err := parseAppointmentTime(in)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseAppointmentTime(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := parseAppointmentTime("next tuesday"); err == nil {
+		t.Error("expected error for unrecognized time")
+	}
+}
+
+func TestSortNotes(t *testing.T) {
+	notes := []Note{
+		{ID: "a", CreatedAt: "2024-01-02T10:00:00Z"},
+		{ID: "b", CreatedAt: "not a date"},
+		{ID: "c", CreatedAt: "2024-03-01 08:00:00"},
+		{ID: "d", CreatedAt: "2023-12-31"},
+	}
+
+	sortNotes(notes, "desc")
+	if got := noteIDs(notes); got != "cadb" {
+		t.Errorf("desc order = %s, want cadb", got)
+	}
+	sortNotes(notes, "asc")
+	if got := noteIDs(notes); got != "dacb" {
+		t.Errorf("asc order = %s, want dacb", got)
+	}
+}
+
+func noteIDs(notes []Note) string {
+	var b strings.Builder
+	for _, n := range notes {
+		b.WriteString(n.ID)
+	}
+	return b.String()
+}
+
+func TestDoCreateWithRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"server error is not resent", []int{http.StatusInternalServerError, http.StatusCreated}, 1, true},
+		{"rate limit is resent", []int{http.StatusTooManyRequests, http.StatusCreated}, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&calls, 1)
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(tt.statuses[n-1])
+				w.Write([]byte(`{"id":"1"}`))
+			}))
+			defer srv.Close()
+
+			client := &fubClient{baseURL: srv.URL, httpClient: srv.Client()}
+			_, err := client.doCreateWithRetry("POST", "/contacts", map[string]string{"name": "Jane"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestSortQuery(t *testing.T) {
+	tests := []struct {
+		resource, sort, order string
+		want                  string
+		wantErr               bool
+	}{
+		{"contacts", "", "", "", false},
+		{"contacts", "created", "", "sort=created", false},
+		{"leads", "price", "DESC", "sort=price&sortDir=desc", false},
+		{"tasks", "dueDate", "asc", "sort=dueDate&sortDir=asc", false},
+		{"events", "dueDate", "", "", true},
+		{"contacts", "crated", "desc", "", true},
+		{"contacts", "created", "down", "", true},
+		{"contacts", "", "desc", "", true},
+	}
+	for _, tt := range tests {
+		got, err := sortQuery(tt.resource, tt.sort, tt.order)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("sortQuery(%q, %q, %q) = %q, %v; want %q, wantErr %v",
+				tt.resource, tt.sort, tt.order, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDeleteContactCmd(t *testing.T) {
+	var gotMethod, gotPath string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod, gotPath = r.Method, r.URL.Path
+		if strings.HasSuffix(r.URL.Path, "/404") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not found"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	cfg := filepath.Join(t.TempDir(), "config.json")
+	data := `{"fub_api_key":"k","fub_system_key":"s","fub_system_name":"n","fub_base_url":"` + srv.URL + `"}`
+	if err := os.WriteFile(cfg, []byte(data), 0600); err != nil {
+		t.Fatal(err)
+	}
+	oldPath := config.Path()
+	config.SetPath(cfg)
+	defer config.SetPath(oldPath)
+
+	tests := []struct {
+		args     []string
+		wantErr  string
+		wantPath string
+	}{
+		{[]string{"42"}, "confirmation_required", ""},
+		{[]string{"42", "--force"}, "", "/contacts/42"},
+		{[]string{"404", "--force"}, "contact_not_found", "/contacts/404"},
+	}
+	for _, tt := range tests {
+		gotMethod, gotPath = "", ""
+		cmd := newDeleteContactCmd()
+		cmd.SetArgs(tt.args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		err := cmd.Execute()
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%v: unexpected error %v", tt.args, err)
+		case tt.wantErr != "" && (err == nil || !strings.HasPrefix(err.Error(), tt.wantErr)):
+			t.Errorf("%v: err = %v, want %s", tt.args, err, tt.wantErr)
+		}
+		if tt.wantPath != "" && (gotMethod != http.MethodDelete || gotPath != tt.wantPath) {
+			t.Errorf("%v: request = %s %s, want DELETE %s", tt.args, gotMethod, gotPath, tt.wantPath)
+		}
+		if tt.wantPath == "" && gotPath != "" {
+			t.Errorf("%v: sent %s %s without --force", tt.args, gotMethod, gotPath)
+		}
+	}
+}
@@ -3,10 +3,20 @@ package contacts
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -17,6 +27,11 @@ import (
 // appleScriptTimeout is the maximum duration for any AppleScript execution.
 const appleScriptTimeout = 30 * time.Second
 
+// bulkScriptTimeout bounds scripts that walk every contact one at a time,
+// such as relabel --all, which take far longer than appleScriptTimeout on
+// large address books
+const bulkScriptTimeout = 10 * time.Minute
+
 // Contact represents a contact in Apple Contacts
 type Contact struct {
 	Name      string    `json:"name"`
@@ -29,6 +44,8 @@ type Contact struct {
 	Notes     string    `json:"notes,omitempty"`
 	Birthday  string    `json:"birthday,omitempty"`
 	JobTitle  string    `json:"job_title,omitempty"`
+	// Groups is populated only when requested with --include-groups
+	Groups []string `json:"groups,omitempty"`
 }
 
 // Email represents an email address with label
@@ -65,6 +82,10 @@ type ContactSummary struct {
 	Email   string `json:"email,omitempty"`
 	Phone   string `json:"phone,omitempty"`
 	Company string `json:"company,omitempty"`
+	// Modified is set only when listing with --modified-since
+	Modified string `json:"modified,omitempty"`
+	// Created is set only when filtering with --created-after
+	Created string `json:"created,omitempty"`
 }
 
 // NewCmd creates the contacts command
@@ -90,9 +111,21 @@ func NewCmd() *cobra.Command {
 	cmd.AddCommand(newListCmd())
 	cmd.AddCommand(newSearchCmd())
 	cmd.AddCommand(newGetCmd())
+	cmd.AddCommand(newDetailsCmd())
 	cmd.AddCommand(newGroupsCmd())
 	cmd.AddCommand(newGroupCmd())
+	cmd.AddCommand(newAddToGroupCmd())
+	cmd.AddCommand(newCreateGroupCmd())
 	cmd.AddCommand(newCreateCmd())
+	cmd.AddCommand(newUpdateCmd())
+	cmd.AddCommand(newDeleteCmd())
+	cmd.AddCommand(newDedupeCmd())
+	cmd.AddCommand(newStatsCmd())
+	cmd.AddCommand(newBirthdaysCmd())
+	cmd.AddCommand(newWatchCmd())
+	cmd.AddCommand(newExportCmd())
+	cmd.AddCommand(newRelabelCmd())
+	cmd.AddCommand(newSetPhotoCmd())
 
 	return cmd
 }
@@ -107,7 +140,12 @@ func escapeAppleScript(s string) string {
 // runOsascript executes an osascript command with a timeout and returns the output.
 // The lang parameter specifies the scripting language ("AppleScript" or "JavaScript").
 func runOsascript(lang string, script string) (string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), appleScriptTimeout)
+	return runOsascriptTimeout(lang, script, appleScriptTimeout)
+}
+
+// runOsascriptTimeout is runOsascript with a caller-chosen timeout
+func runOsascriptTimeout(lang string, script string, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "osascript", "-l", lang, "-e", script)
@@ -118,18 +156,56 @@ func runOsascript(lang string, script string) (string, error) {
 	err := cmd.Run()
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return "", fmt.Errorf("osascript timed out after %s", appleScriptTimeout)
+			return "", fmt.Errorf("osascript timed out after %s", timeout)
 		}
-		errMsg := stderr.String()
+		errMsg := strings.TrimSpace(stderr.String())
 		if errMsg == "" {
 			errMsg = err.Error()
 		}
-		return "", fmt.Errorf("%s", strings.TrimSpace(errMsg))
+		if IsPermissionDenied(errMsg) {
+			return "", &permissionError{Message: errMsg}
+		}
+		return "", fmt.Errorf("%s", errMsg)
 	}
 
 	return strings.TrimSpace(stdout.String()), nil
 }
 
+// permissionError is returned by runOsascript when macOS privacy controls
+// (TCC) block access to Contacts
+type permissionError struct {
+	Message string
+}
+
+func (e *permissionError) Error() string {
+	return e.Message
+}
+
+// IsPermissionDenied reports whether osascript output is a TCC denial
+func IsPermissionDenied(msg string) bool {
+	return strings.Contains(msg, "-1743") || strings.Contains(msg, "Not authorized") ||
+		strings.Contains(msg, "not allowed")
+}
+
+// permissionGuidance tells the user how to grant the access osascript needs
+var permissionGuidance = map[string]string{
+	"settings":   "System Settings > Privacy & Security > Automation, and Privacy & Security > Contacts",
+	"suggestion": "Allow your terminal app to control Contacts and to access Contacts, then retry",
+	"open":       "open 'x-apple.systempreferences:com.apple.preference.security?Privacy_Automation'",
+}
+
+// printScriptError prints err under code, or as permission_denied with
+// guidance when osascript was blocked by privacy controls
+func printScriptError(code string, err error) error {
+	var pe *permissionError
+	if errors.As(err, &pe) {
+		return output.PrintError("permission_denied",
+			"Access to Contacts was denied by macOS privacy settings: "+pe.Message,
+			permissionGuidance)
+	}
+	return output.PrintError(code, err.Error(), nil)
+}
+
 // runAppleScript executes an AppleScript with a timeout and returns the output
 func runAppleScript(script string) (string, error) {
 	return runOsascript("AppleScript", script)
@@ -143,11 +219,18 @@ func runJXA(script string) (string, error) {
 // newListCmd lists all contacts
 func newListCmd() *cobra.Command {
 	var limit int
+	var modifiedSince string
+	var createdAfter string
+	var namesOnly bool
 
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all contacts",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if modifiedSince != "" || createdAfter != "" {
+				return listByDate(modifiedSince, createdAfter, limit, namesOnly)
+			}
+
 			// Use JXA for fast batch property access instead of AppleScript's
 			// per-contact iteration which is extremely slow for large databases.
 			maxResults := limit
@@ -181,10 +264,13 @@ total + '~~~' + results.join(':::');
 
 			result, err := runJXA(script)
 			if err != nil {
-				return output.PrintError("list_failed", err.Error(), nil)
+				return printScriptError("list_failed", err)
 			}
 
 			if result == "" {
+				if namesOnly {
+					return output.Print([]string{})
+				}
 				return output.Print(map[string]any{
 					"contacts": []ContactSummary{},
 					"count":    0,
@@ -203,6 +289,9 @@ total + '~~~' + results.join(':::');
 			}
 
 			if contactData == "" {
+				if namesOnly {
+					return output.Print([]string{})
+				}
 				return output.Print(map[string]any{
 					"contacts": []ContactSummary{},
 					"count":    0,
@@ -216,19 +305,14 @@ total + '~~~' + results.join(':::');
 			for _, item := range items {
 				parts := strings.Split(item, "|||")
 				if len(parts) >= 4 {
-					c := ContactSummary{
-						Name:    strings.TrimSpace(parts[0]),
-						Email:   strings.TrimSpace(parts[1]),
-						Phone:   strings.TrimSpace(parts[2]),
-						Company: strings.TrimSpace(parts[3]),
-					}
-					if c.Company == "null" {
-						c.Company = ""
-					}
-					contacts = append(contacts, c)
+					contacts = append(contacts, summaryFromParts(parts))
 				}
 			}
 
+			if namesOnly {
+				return output.Print(contactNames(contacts))
+			}
+
 			return output.Print(map[string]any{
 				"contacts": contacts,
 				"count":    len(contacts),
@@ -238,10 +322,152 @@ total + '~~~' + results.join(':::');
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "l", 0, "Limit number of contacts (0 = all, default 100)")
+	cmd.Flags().StringVar(&modifiedSince, "modified-since", "", "Only contacts modified after this date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVar(&createdAfter, "created-after", "", "Only contacts created after this date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().BoolVar(&namesOnly, "names-only", false, "Return a flat array of names instead of contact objects")
 
 	return cmd
 }
 
+// scrubNull trims a field from delimited JXA output, mapping the literal
+// "null" that JXA emits for missing properties to ""
+func scrubNull(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "null" {
+		return ""
+	}
+	return s
+}
+
+// summaryFromParts builds a ContactSummary from the name, email, phone and
+// company fields of a list or search result row
+func summaryFromParts(parts []string) ContactSummary {
+	return ContactSummary{
+		Name:    scrubNull(parts[0]),
+		Email:   scrubNull(parts[1]),
+		Phone:   scrubNull(parts[2]),
+		Company: scrubNull(parts[3]),
+	}
+}
+
+// listByDate prints contacts modified after modifiedSince and/or created
+// after createdAfter; an empty date disables that filter
+func listByDate(modifiedSince, createdAfter string, limit int, namesOnly bool) error {
+	var filter dateFilter
+	var err error
+	if modifiedSince != "" {
+		if filter.modifiedAfter, err = parseSinceDate(modifiedSince); err != nil {
+			return output.PrintError("invalid_input", err.Error(), nil)
+		}
+	}
+	if createdAfter != "" {
+		if filter.createdAfter, err = parseSinceDate(createdAfter); err != nil {
+			return output.PrintError("invalid_input", err.Error(), nil)
+		}
+	}
+
+	records, err := fetchContactRecords()
+	if err != nil {
+		return printScriptError("list_failed", err)
+	}
+
+	contacts := filterRecordsByDate(records, filter)
+	total := len(contacts)
+	if limit > 0 && len(contacts) > limit {
+		contacts = contacts[:limit]
+	}
+	if namesOnly {
+		return output.Print(contactNames(contacts))
+	}
+
+	response := map[string]any{
+		"contacts": contacts,
+		"count":    len(contacts),
+		"total":    total,
+	}
+	if !filter.modifiedAfter.IsZero() {
+		response["modified_since"] = filter.modifiedAfter.Format(time.RFC3339)
+	}
+	if !filter.createdAfter.IsZero() {
+		response["created_after"] = filter.createdAfter.Format(time.RFC3339)
+	}
+	return output.Print(response)
+}
+
+// contactNames projects contacts to their names, for callers that only need
+// to pick one
+func contactNames(contacts []ContactSummary) []string {
+	names := make([]string, 0, len(contacts))
+	for _, c := range contacts {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// parseSinceDate accepts a local YYYY-MM-DD date or an RFC3339 timestamp
+func parseSinceDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or RFC3339", s)
+	}
+	return t, nil
+}
+
+// dateFilter selects records by timestamp; zero times are not applied
+type dateFilter struct {
+	modifiedAfter time.Time
+	createdAfter  time.Time
+}
+
+// filterRecordsByDate summarizes the records passing every set filter,
+// newest first: by creation date when filtering on it, else by modification.
+// Each summary carries the timestamps that were filtered on.
+func filterRecordsByDate(records []contactRecord, f dateFilter) []ContactSummary {
+	type dated struct {
+		at      time.Time
+		summary ContactSummary
+	}
+	byCreated := !f.createdAfter.IsZero()
+	var matched []dated
+	for _, r := range records {
+		modified, modErr := time.Parse(time.RFC3339, r.Modified)
+		created, createdErr := time.Parse(time.RFC3339, r.Created)
+		if !f.modifiedAfter.IsZero() && (modErr != nil || !modified.After(f.modifiedAfter)) {
+			continue
+		}
+		if byCreated && (createdErr != nil || !created.After(f.createdAfter)) {
+			continue
+		}
+
+		c := ContactSummary{Name: r.Name, Company: r.Company}
+		if len(r.Emails) > 0 {
+			c.Email = r.Emails[0]
+		}
+		if len(r.Phones) > 0 {
+			c.Phone = r.Phones[0]
+		}
+		at := modified
+		if !f.modifiedAfter.IsZero() {
+			c.Modified = r.Modified
+		}
+		if byCreated {
+			c.Created = r.Created
+			at = created
+		}
+		matched = append(matched, dated{at: at, summary: c})
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].at.After(matched[j].at) })
+
+	contacts := make([]ContactSummary, len(matched))
+	for i, m := range matched {
+		contacts[i] = m.summary
+	}
+	return contacts
+}
+
 // escapeJSString escapes special characters for JavaScript string literals
 func escapeJSString(s string) string {
 	s = strings.ReplaceAll(s, "\\", "\\\\")
@@ -257,6 +483,11 @@ func escapeJSString(s string) string {
 //nolint:gocyclo // sequential JXA script construction with clear logic
 func newSearchCmd() *cobra.Command {
 	var limit int
+	var sortByRelevance bool
+	var phoneNormalized bool
+	var namesOnly bool
+	var group string
+	var createdAfter string
 
 	cmd := &cobra.Command{
 		Use:   "search [query]",
@@ -265,11 +496,34 @@ func newSearchCmd() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			query := args[0]
 
+			// Creation dates are compared in JXA as epoch milliseconds; 0 disables
+			var createdAfterMs int64
+			if createdAfter != "" {
+				cutoff, err := parseSinceDate(createdAfter)
+				if err != nil {
+					return output.PrintError("invalid_input", err.Error(), nil)
+				}
+				createdAfterMs = cutoff.UnixMilli()
+			}
+
 			// Default limit for search to avoid unbounded results
 			maxResults := limit
 			if maxResults <= 0 {
 				maxResults = 50
 			}
+			// Ranking must see every match, so the limit is applied after
+			// sorting rather than while scanning in database order
+			scanLimit := maxResults
+			if sortByRelevance {
+				scanLimit = 0
+			}
+
+			// Phone-like queries also match on digits alone, so 5551234567
+			// finds a number stored as (555) 123-4567
+			queryDigits := ""
+			if phoneNormalized {
+				queryDigits = phoneQueryDigits(query)
+			}
 
 			// Use JXA (JavaScript for Automation) for fast batch property access.
 			// AppleScript's "repeat with p in people" makes individual Apple Event
@@ -280,18 +534,32 @@ func newSearchCmd() *cobra.Command {
 			script := fmt.Sprintf(`
 var app = Application('Contacts');
 var query = '%s'.toLowerCase();
+var queryDigits = '%s';
 var maxResults = %d;
+var groupName = '%s';
+var createdAfter = %d;
+
+// Optionally restrict the search to members of one group
+var source = app.people;
+if (groupName) {
+    var groups = app.groups.whose({name: groupName})();
+    if (groups.length === 0) throw new Error('GROUP_NOT_FOUND');
+    source = groups[0].people;
+}
 
 // Batch-fetch all properties in just 4 Apple Event calls (instead of N*4)
-var names = app.people.name();
-var orgs = app.people.organization();
-var allEmails = app.people.emails.value();
-var allPhones = app.people.phones.value();
+var names = source.name();
+var orgs = source.organization();
+var allEmails = source.emails.value();
+var allPhones = source.phones.value();
+var created = createdAfter ? source.creationDate() : [];
 
 // Find matching contact indices (all in-memory, fast)
 var matchIndices = [];
 var matched = {};
-for (var i = 0; i < names.length && matchIndices.length < maxResults; i++) {
+for (var i = 0; i < names.length && (!maxResults || matchIndices.length < maxResults); i++) {
+    if (createdAfter && !(created[i] && created[i].getTime() > createdAfter)) continue;
+
     var n = (names[i] || '').toLowerCase();
     var o = (orgs[i] && typeof orgs[i] === 'string') ? orgs[i].toLowerCase() : '';
 
@@ -314,7 +582,9 @@ for (var i = 0; i < names.length && matchIndices.length < maxResults; i++) {
     if (!found) {
         var phones = allPhones[i] || [];
         for (var ph = 0; ph < phones.length; ph++) {
-            if (phones[ph] && phones[ph].indexOf(query) >= 0) {
+            if (!phones[ph]) continue;
+            if (phones[ph].indexOf(query) >= 0 ||
+                (queryDigits && phones[ph].replace(/\D/g, '').indexOf(queryDigits) >= 0)) {
                 found = true; break;
             }
         }
@@ -334,17 +604,26 @@ for (var j = 0; j < matchIndices.length; j++) {
     var company = (orgs[idx] && typeof orgs[idx] === 'string') ? orgs[idx] : '';
     var email = (allEmails[idx] && allEmails[idx].length > 0) ? allEmails[idx][0] : '';
     var phone = (allPhones[idx] && allPhones[idx].length > 0) ? allPhones[idx][0] : '';
-    results.push(name + '|||' + email + '|||' + phone + '|||' + company);
+    var createdAt = (createdAfter && created[idx]) ? created[idx].toISOString() : '';
+    results.push(name + '|||' + email + '|||' + phone + '|||' + company + '|||' + createdAt);
 }
 results.join(':::');
-`, escapeJSString(query), maxResults)
+`, escapeJSString(query), queryDigits, scanLimit, escapeJSString(group), createdAfterMs)
 
 			result, err := runJXA(script)
 			if err != nil {
-				return output.PrintError("search_failed", err.Error(), nil)
+				if strings.Contains(err.Error(), "GROUP_NOT_FOUND") {
+					return output.PrintError("group_not_found",
+						fmt.Sprintf("Group not found: %s", group),
+						map[string]string{"name": group})
+				}
+				return printScriptError("search_failed", err)
 			}
 
 			if result == "" {
+				if namesOnly {
+					return output.Print([]string{})
+				}
 				return output.Print(map[string]any{
 					"query":    query,
 					"contacts": []ContactSummary{},
@@ -358,20 +637,21 @@ results.join(':::');
 			for _, item := range items {
 				parts := strings.Split(item, "|||")
 				if len(parts) >= 4 {
-					c := ContactSummary{
-						Name:    strings.TrimSpace(parts[0]),
-						Email:   strings.TrimSpace(parts[1]),
-						Phone:   strings.TrimSpace(parts[2]),
-						Company: strings.TrimSpace(parts[3]),
-					}
-					// JXA returns "null" for missing properties
-					if c.Company == "null" {
-						c.Company = ""
+					c := summaryFromParts(parts)
+					if len(parts) >= 5 {
+						c.Created = scrubNull(parts[4])
 					}
 					contacts = append(contacts, c)
 				}
 			}
 
+			if sortByRelevance {
+				contacts = topByRelevance(contacts, query, maxResults)
+			}
+			if namesOnly {
+				return output.Print(contactNames(contacts))
+			}
+
 			return output.Print(map[string]any{
 				"query":    query,
 				"contacts": contacts,
@@ -381,25 +661,373 @@ results.join(':::');
 	}
 
 	cmd.Flags().IntVarP(&limit, "limit", "l", 0, "Limit number of results (0 = all, default 50)")
+	cmd.Flags().BoolVar(&sortByRelevance, "sort-by-relevance", false, "Rank results: exact name, name prefix, name substring, then company/email/phone matches")
+	cmd.Flags().BoolVar(&phoneNormalized, "phone-normalized", true, "Match phone-number queries on digits only, ignoring formatting")
+	cmd.Flags().BoolVar(&namesOnly, "names-only", false, "Return a flat array of names instead of contact objects")
+	cmd.Flags().StringVarP(&group, "group", "g", "", "Only search members of this group")
+	cmd.Flags().StringVar(&createdAfter, "created-after", "", "Only contacts created after this date (YYYY-MM-DD or RFC3339)")
 
 	return cmd
 }
 
+// topByRelevance ranks every match and keeps the best n
+func topByRelevance(contacts []ContactSummary, query string, n int) []ContactSummary {
+	sortByRelevanceScore(contacts, query)
+	if n > 0 && len(contacts) > n {
+		contacts = contacts[:n]
+	}
+	return contacts
+}
+
+// phoneQueryDigits returns the normalized digits of a query that looks like a
+// phone number (digits plus common punctuation), or "" for any other query
+func phoneQueryDigits(query string) string {
+	for _, r := range query {
+		if !strings.ContainsRune("0123456789 +-().", r) {
+			return ""
+		}
+	}
+	return normalizePhone(query)
+}
+
+// relevanceScore ranks how well a contact matches a search query (lower is better):
+// exact name, name prefix, name substring, then company/email/phone hits.
+func relevanceScore(c ContactSummary, query string) int {
+	q := strings.ToLower(query)
+	name := strings.ToLower(c.Name)
+
+	switch {
+	case name == q:
+		return 0
+	case strings.HasPrefix(name, q):
+		return 1
+	case strings.Contains(name, q):
+		return 2
+	case strings.Contains(strings.ToLower(c.Company), q):
+		return 3
+	case strings.Contains(strings.ToLower(c.Email), q):
+		return 4
+	case strings.Contains(c.Phone, query):
+		return 5
+	default:
+		return 6
+	}
+}
+
+// sortByRelevanceScore orders contacts by relevanceScore, breaking ties by
+// name and keeping the original database order for identical names.
+func sortByRelevanceScore(contacts []ContactSummary, query string) {
+	sort.SliceStable(contacts, func(i, j int) bool {
+		si, sj := relevanceScore(contacts[i], query), relevanceScore(contacts[j], query)
+		if si != sj {
+			return si < sj
+		}
+		return strings.ToLower(contacts[i].Name) < strings.ToLower(contacts[j].Name)
+	})
+}
+
 // newGetCmd gets full contact details by name
 //
 //nolint:gocyclo // complex but clear sequential logic
 func newGetCmd() *cobra.Command {
+	var include []string
+	var exclude []string
+	var format string
+	var explicitNulls bool
+	var includeGroups bool
+	var fuzzy bool
+
 	cmd := &cobra.Command{
 		Use:   "get [name]",
 		Short: "Get full contact details by name",
-		Args:  cobra.ExactArgs(1),
+		Long: `Get full contact details for the contact with exactly this name.
+
+With --fuzzy, a failed exact match falls back to the contacts whose name
+contains the query, ignoring case. A single match is returned in full;
+several return a multiple_matches error listing the candidate names.
+
+Use -o vcard (or --format vcard) to print the contact as a vCard 3.0 entry
+instead of JSON. --format vcard cannot be combined with another -o value.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			contactName := args[0]
 
-			script := fmt.Sprintf(`
+			if err := validateContactFields(append(include, exclude...)); err != nil {
+				return output.PrintError("invalid_input", err.Error(),
+					map[string]any{"supported": contactFieldNames})
+			}
+			serialization, err := getSerialization(format, output.Format())
+			if err != nil {
+				return output.PrintError("invalid_input", err.Error(),
+					map[string]any{"supported": []string{"json", "vcard"}})
+			}
+
+			contact, err := fetchContactDetail(fmt.Sprintf(`first person whose name is "%s"`, escapeAppleScript(contactName)))
+			if se, ok := err.(*scriptError); ok && se.Code == "contact_not_found" && fuzzy {
+				names, ferr := fetchContactNames()
+				if ferr != nil {
+					return printScriptError("get_failed", ferr)
+				}
+				candidates := fuzzyNameMatches(names, contactName)
+				if len(candidates) > 1 {
+					return output.PrintError("multiple_matches",
+						fmt.Sprintf("%d contacts match %s; retry with a more specific name", len(candidates), contactName),
+						map[string]any{"query": contactName, "candidates": candidates})
+				}
+				if len(candidates) == 1 {
+					contact, err = fetchContactDetail(fmt.Sprintf(`first person whose name is "%s"`, escapeAppleScript(candidates[0])))
+				}
+			}
+			if err != nil {
+				if se, ok := err.(*scriptError); ok {
+					if se.Code == "contact_not_found" {
+						return output.PrintError(se.Code,
+							fmt.Sprintf("Contact not found: %s", contactName),
+							map[string]string{"name": contactName})
+					}
+					return output.PrintError(se.Code, se.Message, nil)
+				}
+				return printScriptError("get_failed", err)
+			}
+
+			contact = filterContactFields(contact, include, exclude)
+			if includeGroups {
+				groups, err := fetchContactGroups(contact.Name)
+				if err != nil {
+					return printScriptError("get_failed", err)
+				}
+				contact.Groups = groups
+			}
+			if serialization == "vcard" {
+				_, err := io.WriteString(os.Stdout, formatVCard(contact))
+				return err
+			}
+
+			if explicitNulls {
+				return output.Print(explicitContact(contact, selectedContactFields(include, exclude)))
+			}
+			return output.Print(contact)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&include, "include", nil, "Only return these fields (e.g. emails,phones)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", nil, "Omit these fields (e.g. notes,addresses)")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Serialization: json (honors --output) or vcard; same as -o vcard")
+	cmd.Flags().BoolVar(&explicitNulls, "explicit-nulls", false, "Emit empty strings and arrays instead of omitting empty fields")
+	cmd.Flags().BoolVar(&includeGroups, "include-groups", false, "Also list the groups the contact belongs to")
+	cmd.Flags().BoolVar(&fuzzy, "fuzzy", false, "Fall back to the single contact whose name contains the query")
+
+	return cmd
+}
+
+// getSerialization resolves how get prints a contact from its --format flag
+// and the global --output. vCard is chosen by -o vcard or --format vcard;
+// --format vcard with a non-JSON -o is rejected rather than ignoring one.
+func getSerialization(format, outputFormat string) (string, error) {
+	format = strings.ToLower(format)
+	if format != "json" && format != "vcard" {
+		return "", fmt.Errorf("unsupported format: %s", format)
+	}
+	if outputFormat == "vcard" {
+		return "vcard", nil
+	}
+	if format == "vcard" && outputFormat != "json" {
+		return "", fmt.Errorf("--format vcard conflicts with --output %s", outputFormat)
+	}
+	return format, nil
+}
+
+// fetchContactNames batch-fetches every contact's name in one JXA call
+func fetchContactNames() ([]string, error) {
+	result, err := runJXA(`
+var app = Application('Contacts');
+JSON.stringify(app.people.name().map(function(n) { return n || ''; }));
+`)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	if result == "" {
+		return names, nil
+	}
+	if err := json.Unmarshal([]byte(result), &names); err != nil {
+		return nil, fmt.Errorf("failed to parse contact names: %w", err)
+	}
+	return names, nil
+}
+
+// fuzzyNameMatches returns the distinct names containing query, ignoring
+// case, in address book order
+func fuzzyNameMatches(names []string, query string) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	matches := []string{}
+	if query == "" {
+		return matches
+	}
+	seen := map[string]bool{}
+	for _, n := range names {
+		if n == "" || seen[n] || !strings.Contains(strings.ToLower(n), query) {
+			continue
+		}
+		seen[n] = true
+		matches = append(matches, n)
+	}
+	return matches
+}
+
+// fetchContactGroups returns the names of the groups containing the first
+// person with the given name. Each group's member IDs are read in one batch
+// call, which is faster than walking person.groups per contact.
+func fetchContactGroups(name string) ([]string, error) {
+	script := fmt.Sprintf(`
+var app = Application('Contacts');
+var people = app.people.whose({name: '%s'})();
+if (people.length === 0) throw new Error('CONTACT_NOT_FOUND');
+var id = people[0].id();
+
+var groups = app.groups();
+var names = [];
+for (var i = 0; i < groups.length; i++) {
+    if (groups[i].people.id().indexOf(id) >= 0) names.push(groups[i].name());
+}
+JSON.stringify(names);
+`, escapeJSString(name))
+
+	result, err := runJXA(script)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := []string{}
+	if err := json.Unmarshal([]byte(result), &groups); err != nil {
+		return nil, fmt.Errorf("failed to parse groups: %w", err)
+	}
+	return groups, nil
+}
+
+func newDetailsCmd() *cobra.Command {
+	var include []string
+	var exclude []string
+	var concurrency int
+	var explicitNulls bool
+
+	cmd := &cobra.Command{
+		Use:   "details [name...]",
+		Short: "Get full contact details for several names at once",
+		Long: `Batch counterpart to get: look up every name concurrently in one command and
+return a map of name to contact. Names with no matching contact are listed in
+not_found; other lookup failures are reported per name in errors.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateContactFields(append(include, exclude...)); err != nil {
+				return output.PrintError("invalid_input", err.Error(),
+					map[string]any{"supported": contactFieldNames})
+			}
+
+			found, notFound, failed := fetchContactsByName(args, concurrency)
+			var contacts any = found
+			if explicitNulls {
+				keep := selectedContactFields(include, exclude)
+				explicit := make(map[string]map[string]any, len(found))
+				for name, c := range found {
+					explicit[name] = explicitContact(c, keep)
+				}
+				contacts = explicit
+			} else {
+				for name, c := range found {
+					found[name] = filterContactFields(c, include, exclude)
+				}
+			}
+
+			return output.Print(map[string]any{
+				"count":     len(found),
+				"contacts":  contacts,
+				"not_found": notFound,
+				"errors":    failed,
+			})
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&include, "include", nil, "Only return these fields (e.g. emails,phones)")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", nil, "Omit these fields (e.g. notes,addresses)")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "c", 4, "Maximum concurrent osascript processes")
+	cmd.Flags().BoolVar(&explicitNulls, "explicit-nulls", false, "Emit empty strings and arrays instead of omitting empty fields")
+
+	return cmd
+}
+
+// fetchContactsByName looks up the first contact with each name, with bounded
+// concurrency. Duplicate names are fetched once. It returns the contacts found
+// keyed by name, the names with no match (in input order), and any other
+// failures keyed by name.
+func fetchContactsByName(names []string, concurrency int) (map[string]Contact, []string, map[string]string) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var unique []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, name)
+		}
+	}
+
+	results := make([]Contact, len(unique))
+	errs := make([]error, len(unique))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, name := range unique {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i], errs[i] = fetchContactDetail(fmt.Sprintf(`first person whose name is "%s"`, escapeAppleScript(name)))
+		}(i, name)
+	}
+	wg.Wait()
+
+	found := make(map[string]Contact)
+	notFound := []string{}
+	failed := make(map[string]string)
+	for i, name := range unique {
+		switch err := errs[i].(type) {
+		case nil:
+			found[name] = results[i]
+		case *scriptError:
+			if err.Code == "contact_not_found" {
+				notFound = append(notFound, name)
+			} else {
+				failed[name] = err.Message
+			}
+		default:
+			failed[name] = err.Error()
+		}
+	}
+	return found, notFound, failed
+}
+
+// scriptError is an AppleScript failure with a machine-readable code
+type scriptError struct {
+	Code    string
+	Message string
+}
+
+func (e *scriptError) Error() string {
+	return e.Message
+}
+
+// contactDetailScript is the AppleScript that reads every detail of the
+// person given by the %s reference, e.g. person id "...".
+const contactDetailScript = `
 tell application "Contacts"
 	try
-		set p to first person whose name is "%s"
+		set p to %s
 
 		-- Basic info
 		set fullName to name of p
@@ -476,112 +1104,238 @@ tell application "Contacts"
 	on error errMsg
 		return "ERROR: " & errMsg
 	end try
-end tell`, escapeAppleScript(contactName))
+end tell`
 
-			result, err := runAppleScript(script)
-			if err != nil {
-				return output.PrintError("get_failed", err.Error(), nil)
-			}
+// fetchContactDetail reads the full details of the person matched by
+// selector, an AppleScript person reference.
+func fetchContactDetail(selector string) (Contact, error) {
+	result, err := runAppleScript(fmt.Sprintf(contactDetailScript, selector))
+	if err != nil {
+		if _, ok := err.(*permissionError); ok {
+			return Contact{}, err
+		}
+		return Contact{}, &scriptError{Code: "get_failed", Message: err.Error()}
+	}
 
-			if strings.HasPrefix(result, "ERROR:") {
-				errMsg := strings.TrimPrefix(result, "ERROR: ")
-				if strings.Contains(errMsg, "Can't get person") {
-					return output.PrintError("contact_not_found",
-						fmt.Sprintf("Contact not found: %s", contactName),
-						map[string]string{"name": contactName})
-				}
-				return output.PrintError("get_failed", errMsg, nil)
-			}
+	if strings.HasPrefix(result, "ERROR:") {
+		errMsg := strings.TrimPrefix(result, "ERROR: ")
+		if strings.Contains(errMsg, "Can't get person") {
+			return Contact{}, &scriptError{Code: "contact_not_found", Message: errMsg}
+		}
+		return Contact{}, &scriptError{Code: "get_failed", Message: errMsg}
+	}
 
-			// Parse the result
-			parts := strings.Split(result, "|||")
-			if len(parts) < 10 {
-				return output.PrintError("parse_failed", "Failed to parse contact data", nil)
-			}
-
-			contact := Contact{
-				Name:      strings.TrimSpace(parts[0]),
-				FirstName: strings.TrimSpace(parts[1]),
-				LastName:  strings.TrimSpace(parts[2]),
-				Company:   strings.TrimSpace(parts[3]),
-				JobTitle:  strings.TrimSpace(parts[4]),
-				Notes:     strings.TrimSpace(parts[5]),
-				Birthday:  strings.TrimSpace(parts[6]),
-			}
-
-			// Parse emails
-			emailStr := strings.TrimSpace(parts[7])
-			if emailStr != "" {
-				emailItems := strings.Split(emailStr, ";;;")
-				for _, item := range emailItems {
-					item = strings.TrimSpace(item)
-					if item == "" {
-						continue
-					}
-					emailParts := strings.SplitN(item, "=", 2)
-					if len(emailParts) == 2 {
-						contact.Emails = append(contact.Emails, Email{
-							Label: cleanLabel(emailParts[0]),
-							Value: emailParts[1],
-						})
-					}
-				}
+	return parseContactDetail(result)
+}
+
+// parseContactDetail decodes the delimited output of contactDetailScript
+func parseContactDetail(result string) (Contact, error) {
+	// Parse the result
+	parts := strings.Split(result, "|||")
+	if len(parts) < 10 {
+		return Contact{}, &scriptError{Code: "parse_failed", Message: "Failed to parse contact data"}
+	}
+
+	contact := Contact{
+		Name:      strings.TrimSpace(parts[0]),
+		FirstName: strings.TrimSpace(parts[1]),
+		LastName:  strings.TrimSpace(parts[2]),
+		Company:   strings.TrimSpace(parts[3]),
+		JobTitle:  strings.TrimSpace(parts[4]),
+		Notes:     strings.TrimSpace(parts[5]),
+		Birthday:  strings.TrimSpace(parts[6]),
+	}
+
+	// Parse emails
+	emailStr := strings.TrimSpace(parts[7])
+	if emailStr != "" {
+		emailItems := strings.Split(emailStr, ";;;")
+		for _, item := range emailItems {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			emailParts := strings.SplitN(item, "=", 2)
+			if len(emailParts) == 2 {
+				contact.Emails = append(contact.Emails, Email{
+					Label: cleanLabel(emailParts[0]),
+					Value: emailParts[1],
+				})
 			}
+		}
+	}
 
-			// Parse phones
-			phoneStr := strings.TrimSpace(parts[8])
-			if phoneStr != "" {
-				phoneItems := strings.Split(phoneStr, ";;;")
-				for _, item := range phoneItems {
-					item = strings.TrimSpace(item)
-					if item == "" {
-						continue
-					}
-					phoneParts := strings.SplitN(item, "=", 2)
-					if len(phoneParts) == 2 {
-						contact.Phones = append(contact.Phones, Phone{
-							Label: cleanLabel(phoneParts[0]),
-							Value: phoneParts[1],
-						})
-					}
-				}
+	// Parse phones
+	phoneStr := strings.TrimSpace(parts[8])
+	if phoneStr != "" {
+		phoneItems := strings.Split(phoneStr, ";;;")
+		for _, item := range phoneItems {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
 			}
+			phoneParts := strings.SplitN(item, "=", 2)
+			if len(phoneParts) == 2 {
+				contact.Phones = append(contact.Phones, Phone{
+					Label: cleanLabel(phoneParts[0]),
+					Value: phoneParts[1],
+				})
+			}
+		}
+	}
 
-			// Parse addresses
-			addrStr := strings.TrimSpace(parts[9])
-			if addrStr != "" {
-				addrItems := strings.Split(addrStr, ";;;")
-				for _, item := range addrItems {
-					item = strings.TrimSpace(item)
-					if item == "" {
-						continue
-					}
-					addrParts := strings.SplitN(item, "=", 2)
-					if len(addrParts) == 2 {
-						addrFields := strings.Split(addrParts[1], "|")
-						if len(addrFields) >= 5 {
-							contact.Addresses = append(contact.Addresses, Address{
-								Label:   cleanLabel(addrParts[0]),
-								Street:  addrFields[0],
-								City:    addrFields[1],
-								State:   addrFields[2],
-								Zip:     addrFields[3],
-								Country: addrFields[4],
-							})
-						}
-					}
+	// Parse addresses
+	addrStr := strings.TrimSpace(parts[9])
+	if addrStr != "" {
+		addrItems := strings.Split(addrStr, ";;;")
+		for _, item := range addrItems {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			addrParts := strings.SplitN(item, "=", 2)
+			if len(addrParts) == 2 {
+				addrFields := strings.Split(addrParts[1], "|")
+				if len(addrFields) >= 5 {
+					contact.Addresses = append(contact.Addresses, Address{
+						Label:   cleanLabel(addrParts[0]),
+						Street:  addrFields[0],
+						City:    addrFields[1],
+						State:   addrFields[2],
+						Zip:     addrFields[3],
+						Country: addrFields[4],
+					})
 				}
 			}
-
-			return output.Print(contact)
-		},
+		}
 	}
 
-	return cmd
+	return contact, nil
 }
 
-// cleanLabel removes the special characters from AppleScript labels like "_$!<Home>!$_"
-func cleanLabel(label string) string {
+// contactFieldNames lists the Contact fields selectable via --include/--exclude.
+// The name is always returned so results stay identifiable.
+var contactFieldNames = []string{
+	"first_name", "last_name", "company", "job_title", "emails",
+	"phones", "addresses", "notes", "birthday",
+}
+
+// validateContactFields reports the first unknown field name
+func validateContactFields(fields []string) error {
+	for _, f := range fields {
+		known := false
+		for _, name := range contactFieldNames {
+			if f == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown field: %s", f)
+		}
+	}
+	return nil
+}
+
+// filterContactFields clears fields not selected by include (when non-empty)
+// and any fields listed in exclude. Cleared fields drop out of the JSON output.
+func filterContactFields(c Contact, include, exclude []string) Contact {
+	if len(include) == 0 && len(exclude) == 0 {
+		return c
+	}
+
+	keep := selectedContactFields(include, exclude)
+	out := Contact{Name: c.Name}
+	if keep["first_name"] {
+		out.FirstName = c.FirstName
+	}
+	if keep["last_name"] {
+		out.LastName = c.LastName
+	}
+	if keep["company"] {
+		out.Company = c.Company
+	}
+	if keep["job_title"] {
+		out.JobTitle = c.JobTitle
+	}
+	if keep["emails"] {
+		out.Emails = c.Emails
+	}
+	if keep["phones"] {
+		out.Phones = c.Phones
+	}
+	if keep["addresses"] {
+		out.Addresses = c.Addresses
+	}
+	if keep["notes"] {
+		out.Notes = c.Notes
+	}
+	if keep["birthday"] {
+		out.Birthday = c.Birthday
+	}
+	return out
+}
+
+// selectedContactFields reports, for every name in contactFieldNames, whether
+// it survives include (when non-empty) and exclude
+func selectedContactFields(include, exclude []string) map[string]bool {
+	keep := make(map[string]bool)
+	for _, name := range contactFieldNames {
+		keep[name] = len(include) == 0
+	}
+	for _, f := range include {
+		keep[f] = true
+	}
+	for _, f := range exclude {
+		keep[f] = false
+	}
+	return keep
+}
+
+// explicitContact renders c with every selected field present, using empty
+// strings and arrays instead of omitting keys, so consumers get a stable shape
+func explicitContact(c Contact, keep map[string]bool) map[string]any {
+	emails := make([]map[string]string, 0, len(c.Emails))
+	for _, e := range c.Emails {
+		emails = append(emails, map[string]string{"label": e.Label, "value": e.Value})
+	}
+	phones := make([]map[string]string, 0, len(c.Phones))
+	for _, p := range c.Phones {
+		phones = append(phones, map[string]string{"label": p.Label, "value": p.Value})
+	}
+	addresses := make([]map[string]string, 0, len(c.Addresses))
+	for _, a := range c.Addresses {
+		addresses = append(addresses, map[string]string{
+			"label": a.Label, "street": a.Street, "city": a.City,
+			"state": a.State, "zip": a.Zip, "country": a.Country,
+		})
+	}
+
+	fields := map[string]any{
+		"first_name": c.FirstName,
+		"last_name":  c.LastName,
+		"company":    c.Company,
+		"job_title":  c.JobTitle,
+		"emails":     emails,
+		"phones":     phones,
+		"addresses":  addresses,
+		"notes":      c.Notes,
+		"birthday":   c.Birthday,
+	}
+	out := map[string]any{"name": c.Name}
+	for _, name := range contactFieldNames {
+		if keep[name] {
+			out[name] = fields[name]
+		}
+	}
+	if c.Groups != nil {
+		out["groups"] = c.Groups
+	}
+	return out
+}
+
+// cleanLabel removes the special characters from AppleScript labels like "_$!<Home>!$_"
+func cleanLabel(label string) string {
 	label = strings.TrimPrefix(label, "_$!<")
 	label = strings.TrimSuffix(label, ">!$_")
 	return label
@@ -607,7 +1361,7 @@ end tell`
 
 			result, err := runAppleScript(script)
 			if err != nil {
-				return output.PrintError("groups_failed", err.Error(), nil)
+				return printScriptError("groups_failed", err)
 			}
 
 			if result == "" {
@@ -683,7 +1437,7 @@ end tell`, escapeAppleScript(groupName))
 
 			result, err := runAppleScript(script)
 			if err != nil {
-				return output.PrintError("group_failed", err.Error(), nil)
+				return printScriptError("group_failed", err)
 			}
 
 			if strings.HasPrefix(result, "ERROR:") {
@@ -737,64 +1491,487 @@ end tell`, escapeAppleScript(groupName))
 	return cmd
 }
 
+// newAddToGroupCmd adds an existing contact to an existing group
+func newAddToGroupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-to-group [contact-name] [group-name]",
+		Short: "Add a contact to a group",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contactName, groupName := args[0], args[1]
+
+			result, err := runAppleScript(addToGroupScript(contactName, groupName))
+			if err != nil {
+				return printScriptError("add_to_group_failed", err)
+			}
+
+			switch {
+			case result == "CONTACT_NOT_FOUND":
+				return output.PrintError("contact_not_found",
+					fmt.Sprintf("Contact not found: %s", contactName),
+					map[string]string{"name": contactName})
+			case result == "GROUP_NOT_FOUND":
+				return output.PrintError("group_not_found",
+					fmt.Sprintf("Group not found: %s", groupName),
+					map[string]string{"name": groupName})
+			case strings.HasPrefix(result, "ERROR:"):
+				return output.PrintError("add_to_group_failed", strings.TrimPrefix(result, "ERROR: "), nil)
+			}
+
+			return output.Print(map[string]any{
+				"success":        true,
+				"contact":        contactName,
+				"group":          groupName,
+				"already_member": result == "ALREADY_MEMBER",
+			})
+		},
+	}
+
+	return cmd
+}
+
+// addToGroupScript adds the first person with the given name to the named
+// group, returning CONTACT_NOT_FOUND, GROUP_NOT_FOUND, ALREADY_MEMBER, or
+// ADDED
+func addToGroupScript(contactName, groupName string) string {
+	return fmt.Sprintf(`
+tell application "Contacts"
+	try
+		set p to missing value
+		try
+			set p to first person whose name is "%s"
+		end try
+		if p is missing value then return "CONTACT_NOT_FOUND"
+		set g to missing value
+		try
+			set g to group "%s"
+		end try
+		if g is missing value then return "GROUP_NOT_FOUND"
+		if (id of p) is in (id of people of g) then return "ALREADY_MEMBER"
+		add p to g
+		save
+		return "ADDED"
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, escapeAppleScript(contactName), escapeAppleScript(groupName))
+}
+
+// newCreateGroupCmd creates an empty contact group
+func newCreateGroupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-group [name]",
+		Short: "Create a contact group",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			groupName := args[0]
+
+			result, err := runAppleScript(createGroupScript(groupName))
+			if err != nil {
+				return printScriptError("create_group_failed", err)
+			}
+
+			if result == "GROUP_EXISTS" {
+				return output.PrintError("group_exists",
+					fmt.Sprintf("Group already exists: %s", groupName),
+					map[string]string{"name": groupName})
+			}
+			if strings.HasPrefix(result, "ERROR:") {
+				return output.PrintError("create_group_failed", strings.TrimPrefix(result, "ERROR: "), nil)
+			}
+
+			// Count is spelled out because Group omits a zero count
+			return output.Print(map[string]any{
+				"name":  result,
+				"count": 0,
+			})
+		},
+	}
+
+	return cmd
+}
+
+// createGroupScript makes a group with the given name unless one exists,
+// returning GROUP_EXISTS or the new group's name
+func createGroupScript(name string) string {
+	return fmt.Sprintf(`
+tell application "Contacts"
+	try
+		if exists group "%[1]s" then return "GROUP_EXISTS"
+		set g to make new group with properties {name:"%[1]s"}
+		save
+		return name of g
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, escapeAppleScript(name))
+}
+
 // newCreateCmd creates a new contact
 func newCreateCmd() *cobra.Command {
-	var email string
-	var phone string
+	var emails []string
+	var phones []string
 	var company string
 	var note string
+	var group string
+	var createGroup bool
+	var dedupeCheck bool
+	var force bool
+	var fromStdin bool
+	var addresses []string
+	var addressLabels []string
 
 	cmd := &cobra.Command{
 		Use:   "create [name]",
 		Short: "Create a new contact",
-		Long:  `Create a new contact with the specified name. Optionally add email, phone, company, and notes.`,
-		Args:  cobra.ExactArgs(1),
+		Long: `Create a new contact with the specified name. Optionally add email, phone, company, and notes, and file it into a group.
+
+With --stdin, read a single contact as JSON (the shape returned by get) from
+stdin instead, including multiple emails, phones, and addresses. The response
+lists which fields were applied.
+
+--email and --phone may be repeated and take an optional label prefix, as in
+--email home:me@x.com --email work:me@co.com. Unlabeled emails are filed as
+home and unlabeled phones as mobile.
+
+--address takes "street|city|state|zip|country" (trailing parts may be left
+off) and may be repeated. Each --address-label applies to the address in the
+same position; addresses without one are labeled home.`,
+		Args: cobra.RangeArgs(0, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			name := args[0]
+			var contact Contact
+			if fromStdin {
+				if len(args) > 0 {
+					return output.PrintError("invalid_input", "Pass the name in the JSON, not as an argument, with --stdin", nil)
+				}
+				c, err := decodeContactJSON(os.Stdin)
+				if err != nil {
+					return output.PrintError("invalid_input", err.Error(), nil)
+				}
+				contact = c
+			} else {
+				if len(args) == 0 {
+					return output.PrintError("invalid_input", "Provide a name, or a JSON contact with --stdin", nil)
+				}
+				contact = Contact{Name: args[0], Company: company, Notes: note}
+				for _, spec := range emails {
+					label, value := parseLabeledValue(spec, "home")
+					if value == "" {
+						return output.PrintError("invalid_input", "Empty --email value: "+spec, nil)
+					}
+					contact.Emails = append(contact.Emails, Email{Label: label, Value: value})
+				}
+				for _, spec := range phones {
+					label, value := parseLabeledValue(spec, "mobile")
+					if value == "" {
+						return output.PrintError("invalid_input", "Empty --phone value: "+spec, nil)
+					}
+					contact.Phones = append(contact.Phones, Phone{Label: label, Value: value})
+				}
+				if len(addressLabels) > len(addresses) {
+					return output.PrintError("invalid_input", "More --address-label values than --address values", nil)
+				}
+				for i, spec := range addresses {
+					label := "home"
+					if i < len(addressLabels) {
+						label = addressLabels[i]
+					}
+					addr, err := parseAddressSpec(spec, label)
+					if err != nil {
+						return output.PrintError("invalid_input", err.Error(), nil)
+					}
+					contact.Addresses = append(contact.Addresses, addr)
+				}
+			}
+
+			if dedupeCheck && !force && (len(contact.Emails) > 0 || len(contact.Phones) > 0) {
+				records, err := fetchContactRecords()
+				if err != nil {
+					return printScriptError("create_failed", err)
+				}
+				var match *contactRecord
+				for _, e := range contact.Emails {
+					if match = findIdentityMatch(records, e.Value, ""); match != nil {
+						break
+					}
+				}
+				for _, p := range contact.Phones {
+					if match != nil {
+						break
+					}
+					match = findIdentityMatch(records, "", p.Value)
+				}
+				if match != nil {
+					return output.Print(map[string]any{
+						"created":  false,
+						"message":  "A contact with the same email or phone already exists; pass --force to create anyway",
+						"existing": match,
+					})
+				}
+			}
 
-			// Parse name into first and last
-			nameParts := strings.SplitN(name, " ", 2)
-			firstName := nameParts[0]
-			lastName := ""
-			if len(nameParts) > 1 {
-				lastName = nameParts[1]
+			result, err := runAppleScript(createContactScript(contact, group, createGroup))
+			if err != nil {
+				return printScriptError("create_failed", err)
+			}
+
+			if result == "GROUP_NOT_FOUND" {
+				return output.PrintError("group_not_found",
+					fmt.Sprintf("Group not found: %s", group),
+					map[string]string{
+						"name":       group,
+						"suggestion": "Pass --create-group to create it",
+					})
+			}
+
+			if strings.HasPrefix(result, "ERROR:") {
+				return output.PrintError("create_failed", strings.TrimPrefix(result, "ERROR: "), nil)
 			}
 
-			// Build properties string
-			var propsBuilder strings.Builder
-			propsBuilder.WriteString(fmt.Sprintf(`{first name:"%s"`, escapeAppleScript(firstName))) //nolint:gocritic // AppleScript property syntax requires this format
-			if lastName != "" {
-				propsBuilder.WriteString(fmt.Sprintf(`, last name:"%s"`, escapeAppleScript(lastName))) //nolint:gocritic // AppleScript property syntax requires this format
+			response := map[string]any{
+				"success": true,
+				"created": true,
+				"message": "Contact created successfully",
+				"name":    result,
 			}
-			if company != "" {
-				propsBuilder.WriteString(fmt.Sprintf(`, organization:"%s"`, escapeAppleScript(company))) //nolint:gocritic // AppleScript property syntax requires this format
+			if fromStdin {
+				applied, ignored := appliedContactFields(contact)
+				response["applied"] = applied
+				if len(ignored) > 0 {
+					response["ignored"] = ignored
+				}
+			} else {
+				if len(contact.Emails) > 0 {
+					response["emails"] = contact.Emails
+				}
+				if len(contact.Phones) > 0 {
+					response["phones"] = contact.Phones
+				}
+				if company != "" {
+					response["company"] = company
+				}
+				if note != "" {
+					response["note"] = note
+				}
+				if len(contact.Addresses) > 0 {
+					response["addresses"] = contact.Addresses
+				}
 			}
-			if note != "" {
-				propsBuilder.WriteString(fmt.Sprintf(`, note:"%s"`, escapeAppleScript(note))) //nolint:gocritic // AppleScript property syntax requires this format
+			if group != "" {
+				response["group"] = group
 			}
-			propsBuilder.WriteString("}")
 
-			// Build the script
-			var scriptBuilder strings.Builder
-			scriptBuilder.WriteString(fmt.Sprintf(`
+			return output.Print(response)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&emails, "email", "e", nil, "Email address, optionally label:value (repeatable)")
+	cmd.Flags().StringArrayVarP(&phones, "phone", "p", nil, "Phone number, optionally label:value (repeatable)")
+	cmd.Flags().StringVarP(&company, "company", "c", "", "Company/organization name")
+	cmd.Flags().StringVarP(&note, "note", "n", "", "Notes about the contact")
+	cmd.Flags().StringVarP(&group, "group", "g", "", "Add the new contact to this group")
+	cmd.Flags().BoolVar(&createGroup, "create-group", false, "Create the group if it does not exist")
+	cmd.Flags().BoolVar(&dedupeCheck, "dedupe-check", false, "Return an existing contact with the same email or phone instead of creating")
+	cmd.Flags().BoolVar(&force, "force", false, "Create even if --dedupe-check finds a match")
+	cmd.Flags().BoolVar(&fromStdin, "stdin", false, "Read the contact as JSON from stdin")
+	cmd.MarkFlagsMutuallyExclusive("stdin", "email")
+	cmd.MarkFlagsMutuallyExclusive("stdin", "phone")
+	cmd.MarkFlagsMutuallyExclusive("stdin", "company")
+	cmd.Flags().StringArrayVar(&addresses, "address", nil, "Address as street|city|state|zip|country (repeatable)")
+	cmd.Flags().StringArrayVar(&addressLabels, "address-label", nil, "Label for the --address in the same position (default home)")
+	cmd.MarkFlagsMutuallyExclusive("stdin", "note")
+	cmd.MarkFlagsMutuallyExclusive("stdin", "address")
+	cmd.MarkFlagsMutuallyExclusive("stdin", "address-label")
+
+	return cmd
+}
+
+// parseLabeledValue splits an optional "label:value" prefix off spec. The
+// prefix counts as a label only when it is made of letters, spaces, and
+// hyphens, so values that contain a colon themselves are kept whole.
+// fallback is used when there is no label.
+func parseLabeledValue(spec, fallback string) (label, value string) {
+	spec = strings.TrimSpace(spec)
+	prefix, rest, ok := strings.Cut(spec, ":")
+	prefix = strings.TrimSpace(prefix)
+	if !ok || prefix == "" || strings.TrimLeft(strings.ToLower(prefix), "abcdefghijklmnopqrstuvwxyz -") != "" {
+		return fallback, spec
+	}
+	return prefix, strings.TrimSpace(rest)
+}
+
+// parseAddressSpec parses "street|city|state|zip|country" into an Address.
+// Trailing parts may be omitted, but at least one part must be non-empty.
+func parseAddressSpec(spec, label string) (Address, error) {
+	parts := strings.Split(spec, "|")
+	if len(parts) > 5 {
+		return Address{}, fmt.Errorf("invalid address %q, expected street|city|state|zip|country", spec)
+	}
+	for len(parts) < 5 {
+		parts = append(parts, "")
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	if strings.Join(parts, "") == "" {
+		return Address{}, fmt.Errorf("address %q is empty", spec)
+	}
+	return Address{
+		Label:   strings.TrimSpace(label),
+		Street:  parts[0],
+		City:    parts[1],
+		State:   parts[2],
+		Zip:     parts[3],
+		Country: parts[4],
+	}, nil
+}
+
+// decodeContactJSON reads and validates a single Contact from r. Unknown
+// fields are rejected so typos don't silently drop data.
+func decodeContactJSON(r io.Reader) (Contact, error) {
+	var c Contact
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return Contact{}, fmt.Errorf("invalid contact JSON: %w", err)
+	}
+
+	if strings.TrimSpace(c.Name) == "" && strings.TrimSpace(c.FirstName+c.LastName) == "" {
+		return Contact{}, fmt.Errorf("contact JSON needs a name or first_name/last_name")
+	}
+	for i, e := range c.Emails {
+		if strings.TrimSpace(e.Value) == "" {
+			return Contact{}, fmt.Errorf("emails[%d] has no value", i)
+		}
+	}
+	for i, p := range c.Phones {
+		if strings.TrimSpace(p.Value) == "" {
+			return Contact{}, fmt.Errorf("phones[%d] has no value", i)
+		}
+	}
+	for i, a := range c.Addresses {
+		if a.Street == "" && a.City == "" && a.State == "" && a.Zip == "" && a.Country == "" {
+			return Contact{}, fmt.Errorf("addresses[%d] is empty", i)
+		}
+	}
+	return c, nil
+}
+
+// appliedContactFields lists the fields of c that create sets, and those it
+// was given but cannot set
+func appliedContactFields(c Contact) (applied, ignored []string) {
+	applied = []string{}
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{
+		{"name", c.Name != "" || c.FirstName != "" || c.LastName != ""},
+		{"company", c.Company != ""},
+		{"job_title", c.JobTitle != ""},
+		{"notes", c.Notes != ""},
+		{"emails", len(c.Emails) > 0},
+		{"phones", len(c.Phones) > 0},
+		{"addresses", len(c.Addresses) > 0},
+	} {
+		if f.set {
+			applied = append(applied, f.name)
+		}
+	}
+	// AppleScript date literals depend on the system locale, so birthdays
+	// are not set on create
+	if c.Birthday != "" {
+		ignored = append(ignored, "birthday")
+	}
+	// Group membership comes from --group; the JSON groups list is read-only
+	if len(c.Groups) > 0 {
+		ignored = append(ignored, "groups")
+	}
+	return applied, ignored
+}
+
+// createContactScript builds the AppleScript that creates c and, when group
+// is set, files it into that group (creating it if createGroup is set)
+func createContactScript(c Contact, group string, createGroup bool) string {
+	firstName, lastName := c.FirstName, c.LastName
+	if firstName == "" && lastName == "" {
+		// Parse name into first and last
+		nameParts := strings.SplitN(c.Name, " ", 2)
+		firstName = nameParts[0]
+		if len(nameParts) > 1 {
+			lastName = nameParts[1]
+		}
+	}
+
+	// Build properties string
+	var propsBuilder strings.Builder
+	propsBuilder.WriteString(fmt.Sprintf(`{first name:"%s"`, escapeAppleScript(firstName))) //nolint:gocritic // AppleScript property syntax requires this format
+	if lastName != "" {
+		propsBuilder.WriteString(fmt.Sprintf(`, last name:"%s"`, escapeAppleScript(lastName))) //nolint:gocritic // AppleScript property syntax requires this format
+	}
+	if c.Company != "" {
+		propsBuilder.WriteString(fmt.Sprintf(`, organization:"%s"`, escapeAppleScript(c.Company))) //nolint:gocritic // AppleScript property syntax requires this format
+	}
+	if c.JobTitle != "" {
+		propsBuilder.WriteString(fmt.Sprintf(`, job title:"%s"`, escapeAppleScript(c.JobTitle))) //nolint:gocritic // AppleScript property syntax requires this format
+	}
+	if c.Notes != "" {
+		propsBuilder.WriteString(fmt.Sprintf(`, note:"%s"`, escapeAppleScript(c.Notes))) //nolint:gocritic // AppleScript property syntax requires this format
+	}
+	propsBuilder.WriteString("}")
+
+	// Build the script
+	var scriptBuilder strings.Builder
+	scriptBuilder.WriteString(`
 tell application "Contacts"
 	try
-		set newPerson to make new person with properties %s
+`)
+
+	// Resolve the group before creating the person so a missing group
+	// doesn't leave behind a contact that was never filed.
+	if group != "" {
+		scriptBuilder.WriteString(fmt.Sprintf(`		set targetGroup to missing value
+		try
+			set targetGroup to group "%s"
+		end try
+		if targetGroup is missing value then
+`, escapeAppleScript(group)))
+		if createGroup {
+			scriptBuilder.WriteString(fmt.Sprintf(`			set targetGroup to make new group with properties {name:"%s"}
+`, escapeAppleScript(group)))
+		} else {
+			scriptBuilder.WriteString(`			return "GROUP_NOT_FOUND"
+`)
+		}
+		scriptBuilder.WriteString(`		end if
+`)
+	}
+
+	scriptBuilder.WriteString(fmt.Sprintf(`		set newPerson to make new person with properties %s
 `, propsBuilder.String()))
 
-			// Add email if provided
-			if email != "" {
-				scriptBuilder.WriteString(fmt.Sprintf(`		make new email at end of emails of newPerson with properties {label:"work", value:"%s"}
-`, escapeAppleScript(email)))
-			}
+	for _, e := range c.Emails {
+		scriptBuilder.WriteString(fmt.Sprintf(`		make new email at end of emails of newPerson with properties {label:"%s", value:"%s"}
+`, escapeAppleScript(labelOr(e.Label, "home")), escapeAppleScript(e.Value)))
+	}
 
-			// Add phone if provided
-			if phone != "" {
-				scriptBuilder.WriteString(fmt.Sprintf(`		make new phone at end of phones of newPerson with properties {label:"mobile", value:"%s"}
-`, escapeAppleScript(phone)))
-			}
+	for _, p := range c.Phones {
+		scriptBuilder.WriteString(fmt.Sprintf(`		make new phone at end of phones of newPerson with properties {label:"%s", value:"%s"}
+`, escapeAppleScript(labelOr(p.Label, "mobile")), escapeAppleScript(p.Value)))
+	}
+
+	for _, a := range c.Addresses {
+		scriptBuilder.WriteString(fmt.Sprintf(`		make new address at end of addresses of newPerson with properties {label:"%s", street:"%s", city:"%s", state:"%s", zip:"%s", country:"%s"}
+`, escapeAppleScript(labelOr(a.Label, "home")), escapeAppleScript(a.Street), escapeAppleScript(a.City),
+			escapeAppleScript(a.State), escapeAppleScript(a.Zip), escapeAppleScript(a.Country)))
+	}
+
+	if group != "" {
+		scriptBuilder.WriteString(`		save
+		add newPerson to targetGroup
+`)
+	}
 
-			scriptBuilder.WriteString(`		save
+	scriptBuilder.WriteString(`		save
 		return name of newPerson
 	on error errMsg
 		return "ERROR: " & errMsg
@@ -802,34 +1979,100 @@ tell application "Contacts"
 end tell
 `)
 
-			result, err := runAppleScript(scriptBuilder.String())
-			if err != nil {
-				return output.PrintError("create_failed", err.Error(), nil)
-			}
+	return scriptBuilder.String()
+}
 
-			if strings.HasPrefix(result, "ERROR:") {
-				return output.PrintError("create_failed", strings.TrimPrefix(result, "ERROR: "), nil)
-			}
+// labelOr returns label, or fallback when label is empty
+func labelOr(label, fallback string) string {
+	if label == "" {
+		return fallback
+	}
+	return label
+}
 
-			response := map[string]any{
-				"success": true,
-				"message": "Contact created successfully",
-				"name":    result,
+// contactUpdate holds the properties passed to update; nil fields are left
+// untouched
+type contactUpdate struct {
+	FirstName *string
+	LastName  *string
+	Company   *string
+	Note      *string
+	Email     *string
+	Phone     *string
+}
+
+func (u contactUpdate) empty() bool {
+	return u.FirstName == nil && u.LastName == nil && u.Company == nil &&
+		u.Note == nil && u.Email == nil && u.Phone == nil
+}
+
+// newUpdateCmd modifies an existing contact
+func newUpdateCmd() *cobra.Command {
+	var email string
+	var phone string
+	var company string
+	var note string
+	var firstName string
+	var lastName string
+
+	cmd := &cobra.Command{
+		Use:   "update [name]",
+		Short: "Update an existing contact",
+		Long: `Update the first contact with the given name. Only the properties passed are
+changed; pass an empty value (e.g. --company "") to clear one. --email and
+--phone replace the first email or phone, adding one if the contact has none.
+Prints the updated contact.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contactName := args[0]
+
+			var u contactUpdate
+			if cmd.Flags().Changed("email") {
+				u.Email = &email
+			}
+			if cmd.Flags().Changed("phone") {
+				u.Phone = &phone
 			}
-			if email != "" {
-				response["email"] = email
+			if cmd.Flags().Changed("company") {
+				u.Company = &company
 			}
-			if phone != "" {
-				response["phone"] = phone
+			if cmd.Flags().Changed("note") {
+				u.Note = &note
 			}
-			if company != "" {
-				response["company"] = company
+			if cmd.Flags().Changed("first-name") {
+				u.FirstName = &firstName
 			}
-			if note != "" {
-				response["note"] = note
+			if cmd.Flags().Changed("last-name") {
+				u.LastName = &lastName
+			}
+			if u.empty() {
+				return output.PrintError("invalid_input", "Specify at least one field to update",
+					map[string]any{"supported": []string{"email", "phone", "company", "note", "first-name", "last-name"}})
 			}
 
-			return output.Print(response)
+			result, err := runAppleScript(updateContactScript(contactName, u))
+			if err != nil {
+				return printScriptError("update_failed", err)
+			}
+			if strings.HasPrefix(result, "ERROR:") {
+				errMsg := strings.TrimPrefix(result, "ERROR: ")
+				if strings.Contains(errMsg, "Can't get person") {
+					return output.PrintError("contact_not_found",
+						fmt.Sprintf("Contact not found: %s", contactName),
+						map[string]string{"name": contactName})
+				}
+				return output.PrintError("update_failed", errMsg, nil)
+			}
+
+			contact, err := fetchContactDetail(fmt.Sprintf(`person id "%s"`, escapeAppleScript(result)))
+			if err != nil {
+				if se, ok := err.(*scriptError); ok {
+					return output.PrintError(se.Code, se.Message, nil)
+				}
+				return printScriptError("update_failed", err)
+			}
+
+			return output.Print(contact)
 		},
 	}
 
@@ -837,6 +2080,1803 @@ end tell
 	cmd.Flags().StringVarP(&phone, "phone", "p", "", "Phone number")
 	cmd.Flags().StringVarP(&company, "company", "c", "", "Company/organization name")
 	cmd.Flags().StringVarP(&note, "note", "n", "", "Notes about the contact")
+	cmd.Flags().StringVar(&firstName, "first-name", "", "First name")
+	cmd.Flags().StringVar(&lastName, "last-name", "", "Last name")
 
 	return cmd
 }
+
+// updateContactScript sets the given properties on the first person named
+// name and returns the person's id
+func updateContactScript(name string, u contactUpdate) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`
+tell application "Contacts"
+	try
+		set p to first person whose name is "%s"
+`, escapeAppleScript(name)))
+
+	props := []struct {
+		property string
+		value    *string
+	}{
+		{"first name", u.FirstName},
+		{"last name", u.LastName},
+		{"organization", u.Company},
+		{"note", u.Note},
+	}
+	for _, prop := range props {
+		if prop.value != nil {
+			b.WriteString(fmt.Sprintf(`		set %s of p to "%s"
+`, prop.property, escapeAppleScript(*prop.value)))
+		}
+	}
+
+	if u.Email != nil {
+		b.WriteString(fmt.Sprintf(`		if (count of emails of p) > 0 then
+			set value of first email of p to "%[1]s"
+		else
+			make new email at end of emails of p with properties {label:"work", value:"%[1]s"}
+		end if
+`, escapeAppleScript(*u.Email)))
+	}
+	if u.Phone != nil {
+		b.WriteString(fmt.Sprintf(`		if (count of phones of p) > 0 then
+			set value of first phone of p to "%[1]s"
+		else
+			make new phone at end of phones of p with properties {label:"mobile", value:"%[1]s"}
+		end if
+`, escapeAppleScript(*u.Phone)))
+	}
+
+	b.WriteString(`		save
+		return id of p
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell
+`)
+	return b.String()
+}
+
+// newDeleteCmd deletes a contact by exact name
+func newDeleteCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Delete a contact",
+		Long: `Delete the contact whose name exactly matches. Deletion cannot be undone, so
+--yes is required. When several contacts share the name nothing is deleted
+and the matches are listed so you can disambiguate.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			contactName := args[0]
+
+			if !yes {
+				return output.PrintError("confirmation_required",
+					fmt.Sprintf("Deleting %s cannot be undone; pass --yes to confirm", contactName),
+					map[string]string{"name": contactName})
+			}
+
+			result, err := runAppleScript(deleteContactScript(contactName))
+			if err != nil {
+				return printScriptError("delete_failed", err)
+			}
+
+			switch {
+			case result == "NOT_FOUND":
+				return output.PrintError("contact_not_found",
+					fmt.Sprintf("Contact not found: %s", contactName),
+					map[string]string{"name": contactName})
+			case strings.HasPrefix(result, "MULTIPLE:"):
+				matches := parseDeleteMatches(strings.TrimPrefix(result, "MULTIPLE:"))
+				return output.PrintError("multiple_matches",
+					fmt.Sprintf("%d contacts are named %s; none were deleted", len(matches), contactName),
+					map[string]any{"name": contactName, "matches": matches})
+			case strings.HasPrefix(result, "ERROR:"):
+				return output.PrintError("delete_failed", strings.TrimPrefix(result, "ERROR: "), nil)
+			}
+
+			return output.Print(map[string]any{
+				"success": true,
+				"deleted": true,
+				"name":    contactName,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "Confirm the deletion")
+
+	return cmd
+}
+
+// deleteContactScript deletes the only person with exactly the given name.
+// It returns NOT_FOUND, or MULTIPLE: followed by each match's
+// name|||email|||phone|||company joined with ::: when the name is ambiguous.
+func deleteContactScript(name string) string {
+	return fmt.Sprintf(`
+tell application "Contacts"
+	try
+		set matches to every person whose name is "%s"
+		if (count of matches) is 0 then return "NOT_FOUND"
+		if (count of matches) > 1 then
+			set matchList to {}
+			repeat with p in matches
+				set primaryEmail to ""
+				set primaryPhone to ""
+				set companyName to ""
+				try
+					set primaryEmail to value of first email of p
+				end try
+				try
+					set primaryPhone to value of first phone of p
+				end try
+				try
+					set companyName to organization of p
+					if companyName is missing value then set companyName to ""
+				end try
+				set end of matchList to (name of p) & "|||" & primaryEmail & "|||" & primaryPhone & "|||" & companyName
+			end repeat
+			set AppleScript's text item delimiters to ":::"
+			return "MULTIPLE:" & (matchList as text)
+		end if
+		delete item 1 of matches
+		save
+		return "DELETED"
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, escapeAppleScript(name))
+}
+
+// parseDeleteMatches parses the matches listed by deleteContactScript
+func parseDeleteMatches(result string) []ContactSummary {
+	matches := []ContactSummary{}
+	for _, item := range strings.Split(result, ":::") {
+		parts := strings.Split(item, "|||")
+		if len(parts) >= 4 {
+			matches = append(matches, summaryFromParts(parts))
+		}
+	}
+	return matches
+}
+
+// findIdentityMatch returns the first record sharing the normalized email or
+// phone, or nil when there is none
+func findIdentityMatch(records []contactRecord, email, phone string) *contactRecord {
+	wantEmail := normalizeEmail(email)
+	wantPhone := normalizePhone(phone)
+	for i := range records {
+		for _, e := range records[i].Emails {
+			if wantEmail != "" && normalizeEmail(e) == wantEmail {
+				return &records[i]
+			}
+		}
+		for _, p := range records[i].Phones {
+			if wantPhone != "" && normalizePhone(p) == wantPhone {
+				return &records[i]
+			}
+		}
+	}
+	return nil
+}
+
+// contactRecord is a full-database snapshot of one contact, fetched in bulk
+// via JXA for commands that need to compare contacts against each other.
+type contactRecord struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Company  string   `json:"company"`
+	JobTitle string   `json:"job_title"`
+	Emails   []string `json:"emails"`
+	Phones   []string `json:"phones"`
+	Created  string   `json:"created"`
+	Modified string   `json:"modified"`
+}
+
+// fetchContactRecords batch-fetches every contact as a contactRecord.
+// JXA returns null for missing properties, which decode to zero values.
+func fetchContactRecords() ([]contactRecord, error) {
+	script := `
+var app = Application('Contacts');
+var ids = app.people.id();
+var names = app.people.name();
+var orgs = app.people.organization();
+var titles = app.people.jobTitle();
+var allEmails = app.people.emails.value();
+var allPhones = app.people.phones.value();
+var created = app.people.creationDate();
+var modified = app.people.modificationDate();
+
+var results = [];
+for (var i = 0; i < ids.length; i++) {
+    results.push({
+        id: ids[i],
+        name: names[i] || '',
+        company: (typeof orgs[i] === 'string') ? orgs[i] : '',
+        job_title: (typeof titles[i] === 'string') ? titles[i] : '',
+        emails: allEmails[i] || [],
+        phones: allPhones[i] || [],
+        created: created[i] ? created[i].toISOString() : '',
+        modified: modified[i] ? modified[i].toISOString() : ''
+    });
+}
+JSON.stringify(results);
+`
+
+	result, err := runJXA(script)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []contactRecord
+	if result == "" {
+		return records, nil
+	}
+	if err := json.Unmarshal([]byte(result), &records); err != nil {
+		return nil, fmt.Errorf("failed to parse contacts: %w", err)
+	}
+	return records, nil
+}
+
+// normalizeEmail lowercases and trims an email for comparison
+func normalizeEmail(e string) string {
+	return strings.ToLower(strings.TrimSpace(e))
+}
+
+// normalizePhone strips everything but digits so that differently formatted
+// numbers compare equal. Numbers too short to be meaningful return "".
+func normalizePhone(p string) string {
+	var b strings.Builder
+	for _, r := range p {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	digits := b.String()
+	if len(digits) < 7 {
+		return ""
+	}
+	// Compare on the last 10 digits so "+1 555..." matches "555..."
+	if len(digits) > 10 {
+		digits = digits[len(digits)-10:]
+	}
+	return digits
+}
+
+// findDuplicateClusters groups records that share a normalized email or phone.
+// Clusters are returned as index lists in database order; singletons are omitted.
+func findDuplicateClusters(records []contactRecord) [][]int {
+	parent := make([]int, len(records))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int) {
+		ra, rb := find(a), find(b)
+		if ra == rb {
+			return
+		}
+		if ra < rb {
+			parent[rb] = ra
+		} else {
+			parent[ra] = rb
+		}
+	}
+
+	seen := make(map[string]int)
+	for i, r := range records {
+		var keys []string
+		for _, e := range r.Emails {
+			if k := normalizeEmail(e); k != "" {
+				keys = append(keys, "e:"+k)
+			}
+		}
+		for _, p := range r.Phones {
+			if k := normalizePhone(p); k != "" {
+				keys = append(keys, "p:"+k)
+			}
+		}
+		for _, k := range keys {
+			if j, ok := seen[k]; ok {
+				union(i, j)
+			} else {
+				seen[k] = i
+			}
+		}
+	}
+
+	groups := make(map[int][]int)
+	var roots []int
+	for i := range records {
+		root := find(i)
+		if _, ok := groups[root]; !ok {
+			roots = append(roots, root)
+		}
+		groups[root] = append(groups[root], i)
+	}
+
+	var clusters [][]int
+	for _, root := range roots {
+		if len(groups[root]) > 1 {
+			clusters = append(clusters, groups[root])
+		}
+	}
+	return clusters
+}
+
+// fieldCount counts the populated fields of a record, used to find the most
+// complete contact in a cluster.
+func fieldCount(r contactRecord) int {
+	n := len(r.Emails) + len(r.Phones)
+	for _, f := range []string{r.Name, r.Company, r.JobTitle} {
+		if f != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// pickSurvivor chooses which record in a cluster to keep.
+// keep is one of "oldest", "newest", or "most-fields"; ties go to the
+// earliest record in the cluster.
+func pickSurvivor(records []contactRecord, cluster []int, keep string) int {
+	best := cluster[0]
+	for _, i := range cluster[1:] {
+		switch keep {
+		case "oldest":
+			if records[i].Created != "" && (records[best].Created == "" || records[i].Created < records[best].Created) {
+				best = i
+			}
+		case "newest":
+			if records[i].Created > records[best].Created {
+				best = i
+			}
+		default:
+			if fieldCount(records[i]) > fieldCount(records[best]) {
+				best = i
+			}
+		}
+	}
+	return best
+}
+
+// mergeDetail is what dedupe --merge reads about a cluster member beyond
+// contactRecord: labeled values it can copy onto the survivor, plus the
+// kinds of data (Other) it has no way to carry over.
+type mergeDetail struct {
+	Emails    []Email      `json:"emails"`
+	Phones    []Phone      `json:"phones"`
+	Addresses []Address    `json:"addresses"`
+	URLs      []contactURL `json:"urls"`
+	Note      string       `json:"note"`
+	Birthday  string       `json:"birthday"`
+	Other     []string     `json:"other"`
+}
+
+// contactURL is a labeled URL on a contact
+type contactURL struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// fetchMergeDetails reads a mergeDetail for each ID in one JXA call. Labels
+// are kept raw (e.g. "_$!<Home>!$_") so they can be written back unchanged.
+func fetchMergeDetails(ids []string) (map[string]mergeDetail, error) {
+	details := make(map[string]mergeDetail)
+	if len(ids) == 0 {
+		return details, nil
+	}
+	idsJSON, err := json.Marshal(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	script := fmt.Sprintf(`
+var app = Application('Contacts');
+var ids = %s;
+function labeled(items) {
+    return items.map(function(x) { return {label: x.label() || '', value: x.value() || ''}; });
+}
+var out = {};
+for (var i = 0; i < ids.length; i++) {
+    var p = app.people.byId(ids[i]);
+    var bd = p.birthDate();
+    var other = [];
+    if (p.relatedNames().length) other.push('related_names');
+    if (p.socialProfiles().length) other.push('social_profiles');
+    if (p.instantMessages().length) other.push('instant_messages');
+    if (p.customDates().length) other.push('custom_dates');
+    out[ids[i]] = {
+        emails: labeled(p.emails()),
+        phones: labeled(p.phones()),
+        urls: labeled(p.urls()),
+        addresses: p.addresses().map(function(a) {
+            return {label: a.label() || '', street: a.street() || '', city: a.city() || '',
+                state: a.state() || '', zip: a.zip() || '', country: a.country() || ''};
+        }),
+        note: p.note() || '',
+        birthday: bd ? bd.toISOString() : '',
+        other: other
+    };
+}
+JSON.stringify(out);
+`, idsJSON)
+
+	result, err := runJXA(script)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(result), &details); err != nil {
+		return nil, fmt.Errorf("failed to parse contact details: %w", err)
+	}
+	return details, nil
+}
+
+// detailFor returns the mergeDetail for r, falling back to its unlabeled
+// emails and phones when no detail was fetched
+func detailFor(r contactRecord, details map[string]mergeDetail) mergeDetail {
+	if d, ok := details[r.ID]; ok {
+		return d
+	}
+	var d mergeDetail
+	for _, e := range r.Emails {
+		d.Emails = append(d.Emails, Email{Value: e})
+	}
+	for _, p := range r.Phones {
+		d.Phones = append(d.Phones, Phone{Value: p})
+	}
+	return d
+}
+
+// mergeReview is a duplicate left in place because merging it would lose data
+type mergeReview struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Conflicts []string `json:"conflicts"`
+}
+
+// mergePlan describes how duplicates fold into a surviving contact.
+// Duplicates are merged and deleted; NeedsReview ones are left untouched.
+type mergePlan struct {
+	Survivor     contactRecord
+	Duplicates   []contactRecord
+	NeedsReview  []mergeReview
+	AddEmails    []Email
+	AddPhones    []Phone
+	AddAddresses []Address
+	AddURLs      []contactURL
+	SetCompany   string
+	SetJobTitle  string
+	NoteFrom     string // ID of the duplicate whose note is copied
+	BirthdayFrom string // ID of the duplicate whose birthday is copied
+}
+
+// addressKey identifies an address for comparison, ignoring its label
+func addressKey(a Address) string {
+	return strings.ToLower(strings.Join([]string{
+		strings.TrimSpace(a.Street), strings.TrimSpace(a.City), strings.TrimSpace(a.State),
+		strings.TrimSpace(a.Zip), strings.TrimSpace(a.Country),
+	}, "|"))
+}
+
+// planMerge unions the duplicates' emails, phones, addresses and URLs (with
+// their labels) into the survivor, and fills in company, job title, note and
+// birthday when the survivor lacks them. A duplicate whose single-valued
+// fields conflict with the survivor's, or that holds data merge cannot copy,
+// is not merged and goes to NeedsReview instead.
+func planMerge(survivor contactRecord, duplicates []contactRecord, details map[string]mergeDetail) mergePlan {
+	plan := mergePlan{Survivor: survivor}
+	sd := detailFor(survivor, details)
+
+	haveEmail := make(map[string]bool)
+	for _, e := range sd.Emails {
+		haveEmail[normalizeEmail(e.Value)] = true
+	}
+	havePhone := make(map[string]bool)
+	for _, p := range sd.Phones {
+		havePhone[phoneKey(p.Value)] = true
+	}
+	haveAddress := make(map[string]bool)
+	for _, a := range sd.Addresses {
+		haveAddress[addressKey(a)] = true
+	}
+	haveURL := make(map[string]bool)
+	for _, u := range sd.URLs {
+		haveURL[strings.ToLower(strings.TrimSpace(u.Value))] = true
+	}
+	company, jobTitle := survivor.Company, survivor.JobTitle
+	note, birthday := strings.TrimSpace(sd.Note), sd.Birthday
+
+	for _, d := range duplicates {
+		dd := detailFor(d, details)
+		dNote := strings.TrimSpace(dd.Note)
+
+		conflicts := append([]string{}, dd.Other...)
+		for _, f := range []struct{ name, have, dup string }{
+			{"company", company, d.Company},
+			{"job_title", jobTitle, d.JobTitle},
+			{"notes", note, dNote},
+			{"birthday", birthday, dd.Birthday},
+		} {
+			if f.have != "" && f.dup != "" && f.have != f.dup {
+				conflicts = append(conflicts, f.name)
+			}
+		}
+		if len(conflicts) > 0 {
+			plan.NeedsReview = append(plan.NeedsReview, mergeReview{ID: d.ID, Name: d.Name, Conflicts: conflicts})
+			continue
+		}
+		plan.Duplicates = append(plan.Duplicates, d)
+
+		for _, e := range dd.Emails {
+			k := normalizeEmail(e.Value)
+			if k != "" && !haveEmail[k] {
+				haveEmail[k] = true
+				plan.AddEmails = append(plan.AddEmails, e)
+			}
+		}
+		for _, p := range dd.Phones {
+			if k := phoneKey(p.Value); !havePhone[k] {
+				havePhone[k] = true
+				plan.AddPhones = append(plan.AddPhones, p)
+			}
+		}
+		for _, a := range dd.Addresses {
+			if k := addressKey(a); !haveAddress[k] {
+				haveAddress[k] = true
+				plan.AddAddresses = append(plan.AddAddresses, a)
+			}
+		}
+		for _, u := range dd.URLs {
+			k := strings.ToLower(strings.TrimSpace(u.Value))
+			if k != "" && !haveURL[k] {
+				haveURL[k] = true
+				plan.AddURLs = append(plan.AddURLs, u)
+			}
+		}
+		if company == "" && d.Company != "" {
+			company = d.Company
+			plan.SetCompany = d.Company
+		}
+		if jobTitle == "" && d.JobTitle != "" {
+			jobTitle = d.JobTitle
+			plan.SetJobTitle = d.JobTitle
+		}
+		if note == "" && dNote != "" {
+			note = dNote
+			plan.NoteFrom = d.ID
+		}
+		if birthday == "" && dd.Birthday != "" {
+			birthday = dd.Birthday
+			plan.BirthdayFrom = d.ID
+		}
+	}
+
+	return plan
+}
+
+// phoneKey is the comparison key for a phone: its normalized digits, or the
+// raw value when it is too short to normalize
+func phoneKey(p string) string {
+	if k := normalizePhone(p); k != "" {
+		return k
+	}
+	return p
+}
+
+// mergeScript builds the AppleScript that applies a merge plan: it copies
+// the unioned fields onto the survivor, keeping their labels, and deletes
+// the merged duplicates. Duplicates needing review are not touched.
+func mergeScript(plan mergePlan) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`
+tell application "Contacts"
+	try
+		set p to person id "%s"
+`, escapeAppleScript(plan.Survivor.ID)))
+	for _, e := range plan.AddEmails {
+		b.WriteString(fmt.Sprintf(`		make new email at end of emails of p with properties {label:"%s", value:"%s"}
+`, escapeAppleScript(labelOr(e.Label, "other")), escapeAppleScript(e.Value)))
+	}
+	for _, ph := range plan.AddPhones {
+		b.WriteString(fmt.Sprintf(`		make new phone at end of phones of p with properties {label:"%s", value:"%s"}
+`, escapeAppleScript(labelOr(ph.Label, "other")), escapeAppleScript(ph.Value)))
+	}
+	for _, a := range plan.AddAddresses {
+		b.WriteString(fmt.Sprintf(`		make new address at end of addresses of p with properties {label:"%s", street:"%s", city:"%s", state:"%s", zip:"%s", country:"%s"}
+`, escapeAppleScript(labelOr(a.Label, "other")), escapeAppleScript(a.Street), escapeAppleScript(a.City),
+			escapeAppleScript(a.State), escapeAppleScript(a.Zip), escapeAppleScript(a.Country)))
+	}
+	for _, u := range plan.AddURLs {
+		b.WriteString(fmt.Sprintf(`		make new url at end of urls of p with properties {label:"%s", value:"%s"}
+`, escapeAppleScript(labelOr(u.Label, "other")), escapeAppleScript(u.Value)))
+	}
+	if plan.SetCompany != "" {
+		b.WriteString(fmt.Sprintf(`		set organization of p to "%s"
+`, escapeAppleScript(plan.SetCompany)))
+	}
+	if plan.SetJobTitle != "" {
+		b.WriteString(fmt.Sprintf(`		set job title of p to "%s"
+`, escapeAppleScript(plan.SetJobTitle)))
+	}
+	// Note and birthday are copied person-to-person so their text and date
+	// values round-trip exactly
+	if plan.NoteFrom != "" {
+		b.WriteString(fmt.Sprintf(`		set note of p to note of person id "%s"
+`, escapeAppleScript(plan.NoteFrom)))
+	}
+	if plan.BirthdayFrom != "" {
+		b.WriteString(fmt.Sprintf(`		set birth date of p to birth date of person id "%s"
+`, escapeAppleScript(plan.BirthdayFrom)))
+	}
+	for _, d := range plan.Duplicates {
+		b.WriteString(fmt.Sprintf(`		delete person id "%s"
+`, escapeAppleScript(d.ID)))
+	}
+	b.WriteString(`		save
+		return "OK"
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell
+`)
+	return b.String()
+}
+
+// newDedupeCmd finds duplicate clusters and optionally merges them
+func newDedupeCmd() *cobra.Command {
+	var merge bool
+	var keep string
+
+	cmd := &cobra.Command{
+		Use:   "dedupe",
+		Short: "Find (and optionally merge) contacts sharing an email or phone",
+		Long: `Find clusters of contacts that share a normalized email address or phone number.
+
+By default this is a dry run that only reports what would be merged. Pass --merge
+to fold each cluster into a single survivor: emails, phones, addresses and URLs are
+unioned with their labels, missing company, job title, note and birthday are filled
+in, and the merged duplicates are deleted.
+
+A duplicate is never deleted when that would lose data: if its company, job title,
+note or birthday differs from the survivor's, or it has related names, social
+profiles, instant messages or custom dates, it is left in place and listed under
+needs_review with the conflicting fields.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch keep {
+			case "oldest", "newest", "most-fields":
+			default:
+				return output.PrintError("invalid_input",
+					fmt.Sprintf("Invalid --keep value: %s", keep),
+					map[string]string{"supported": "oldest, newest, most-fields"})
+			}
+
+			records, err := fetchContactRecords()
+			if err != nil {
+				return printScriptError("dedupe_failed", err)
+			}
+
+			clusters := findDuplicateClusters(records)
+			var memberIDs []string
+			for _, cluster := range clusters {
+				for _, i := range cluster {
+					memberIDs = append(memberIDs, records[i].ID)
+				}
+			}
+			details, err := fetchMergeDetails(memberIDs)
+			if err != nil {
+				return printScriptError("dedupe_failed", err)
+			}
+
+			report := make([]map[string]any, 0, len(clusters))
+			merged, needsReview := 0, 0
+
+			for _, cluster := range clusters {
+				survivorIdx := pickSurvivor(records, cluster, keep)
+				var duplicates []contactRecord
+				for _, i := range cluster {
+					if i != survivorIdx {
+						duplicates = append(duplicates, records[i])
+					}
+				}
+				plan := planMerge(records[survivorIdx], duplicates, details)
+
+				dupNames := make([]map[string]string, 0, len(plan.Duplicates))
+				for _, d := range plan.Duplicates {
+					dupNames = append(dupNames, map[string]string{"id": d.ID, "name": d.Name})
+				}
+				addedEmails := make([]string, 0, len(plan.AddEmails))
+				for _, e := range plan.AddEmails {
+					addedEmails = append(addedEmails, e.Value)
+				}
+				addedPhones := make([]string, 0, len(plan.AddPhones))
+				for _, p := range plan.AddPhones {
+					addedPhones = append(addedPhones, p.Value)
+				}
+
+				entry := map[string]any{
+					"survivor":     map[string]string{"id": plan.Survivor.ID, "name": plan.Survivor.Name},
+					"duplicates":   dupNames,
+					"added_emails": addedEmails,
+					"added_phones": addedPhones,
+					"action":       "would_merge",
+				}
+				if len(plan.AddAddresses) > 0 {
+					entry["added_addresses"] = len(plan.AddAddresses)
+				}
+				if len(plan.AddURLs) > 0 {
+					entry["added_urls"] = len(plan.AddURLs)
+				}
+				if plan.SetCompany != "" {
+					entry["set_company"] = plan.SetCompany
+				}
+				if plan.SetJobTitle != "" {
+					entry["set_job_title"] = plan.SetJobTitle
+				}
+				if len(plan.NeedsReview) > 0 {
+					entry["needs_review"] = plan.NeedsReview
+					needsReview += len(plan.NeedsReview)
+				}
+				if len(plan.Duplicates) == 0 {
+					entry["action"] = "needs_review"
+				}
+
+				if merge && len(plan.Duplicates) > 0 {
+					result, err := runAppleScript(mergeScript(plan))
+					switch {
+					case err != nil:
+						entry["action"] = "failed"
+						entry["error"] = err.Error()
+					case strings.HasPrefix(result, "ERROR:"):
+						entry["action"] = "failed"
+						entry["error"] = strings.TrimPrefix(result, "ERROR: ")
+					default:
+						entry["action"] = "merged"
+						merged++
+					}
+				}
+
+				report = append(report, entry)
+			}
+
+			return output.Print(map[string]any{
+				"dry_run":      !merge,
+				"keep":         keep,
+				"clusters":     report,
+				"count":        len(report),
+				"merged":       merged,
+				"needs_review": needsReview,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&merge, "merge", false, "Merge each cluster (default is a report-only dry run)")
+	cmd.Flags().StringVar(&keep, "keep", "most-fields", "Survivor selection: oldest, newest, most-fields")
+
+	return cmd
+}
+
+// CompanyCount is the number of contacts at a company
+type CompanyCount struct {
+	Company string `json:"company"`
+	Count   int    `json:"count"`
+}
+
+// companyHistogram counts contacts per company (case-insensitive), sorted by
+// count descending then name. It also returns how many have no company.
+func companyHistogram(orgs []string) (counts []CompanyCount, noCompany int) {
+	index := make(map[string]int)
+	for _, org := range orgs {
+		org = strings.TrimSpace(org)
+		if org == "" || org == "null" {
+			noCompany++
+			continue
+		}
+		key := strings.ToLower(org)
+		if i, ok := index[key]; ok {
+			counts[i].Count++
+			continue
+		}
+		index[key] = len(counts)
+		counts = append(counts, CompanyCount{Company: org, Count: 1})
+	}
+
+	sort.SliceStable(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return strings.ToLower(counts[i].Company) < strings.ToLower(counts[j].Company)
+	})
+	return counts, noCompany
+}
+
+// newStatsCmd reports aggregate statistics about the address book
+func newStatsCmd() *cobra.Command {
+	var top int
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show contacts-per-company histogram",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			script := `
+var app = Application('Contacts');
+var orgs = app.people.organization();
+var results = [];
+for (var i = 0; i < orgs.length; i++) {
+    results.push((orgs[i] && typeof orgs[i] === 'string') ? orgs[i] : '');
+}
+JSON.stringify(results);
+`
+			result, err := runJXA(script)
+			if err != nil {
+				return printScriptError("stats_failed", err)
+			}
+
+			var orgs []string
+			if result != "" {
+				if err := json.Unmarshal([]byte(result), &orgs); err != nil {
+					return output.PrintError("parse_failed", err.Error(), nil)
+				}
+			}
+
+			counts, noCompany := companyHistogram(orgs)
+			distinct := len(counts)
+			if top > 0 && len(counts) > top {
+				counts = counts[:top]
+			}
+			if counts == nil {
+				counts = []CompanyCount{}
+			}
+
+			return output.Print(map[string]any{
+				"total_contacts":     len(orgs),
+				"distinct_companies": distinct,
+				"no_company":         noCompany,
+				"companies":          counts,
+			})
+		},
+	}
+
+	cmd.Flags().IntVarP(&top, "top", "n", 20, "Number of companies to return (0 = all)")
+
+	cmd.AddCommand(newBirthdaysByMonthCmd())
+
+	return cmd
+}
+
+// noYearSentinel is the year Apple Contacts stores for birthdays entered
+// without a year
+const noYearSentinel = 1604
+
+// birthdayRecord is a contact's birthday split into local date components.
+// Year is 0 when the birthday was saved without one.
+type birthdayRecord struct {
+	Name  string `json:"name"`
+	Month int    `json:"month"`
+	Day   int    `json:"day"`
+	Year  int    `json:"year"`
+}
+
+// fetchBirthdays batch-fetches every contact that has a birthday. Components
+// are read in local time so a midnight birthday doesn't shift a day in UTC.
+func fetchBirthdays() ([]birthdayRecord, error) {
+	script := `
+var app = Application('Contacts');
+var names = app.people.name();
+var births = app.people.birthDate();
+var results = [];
+for (var i = 0; i < names.length; i++) {
+    var d = births[i];
+    if (!d) continue;
+    results.push({
+        name: names[i] || '',
+        month: d.getMonth() + 1,
+        day: d.getDate(),
+        year: d.getFullYear()
+    });
+}
+JSON.stringify(results);
+`
+	result, err := runJXA(script)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []birthdayRecord
+	if result == "" {
+		return records, nil
+	}
+	if err := json.Unmarshal([]byte(result), &records); err != nil {
+		return nil, fmt.Errorf("failed to parse birthdays: %w", err)
+	}
+	for i := range records {
+		if records[i].Year == noYearSentinel {
+			records[i].Year = 0
+		}
+	}
+	return records, nil
+}
+
+// MonthCount is the number of contacts with a birthday in a month
+type MonthCount struct {
+	Month int    `json:"month"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// birthdayHistogram buckets birthdays by month, always returning all twelve
+// months in calendar order. It also returns how many have no birth year.
+func birthdayHistogram(records []birthdayRecord) (months []MonthCount, noYear int) {
+	months = make([]MonthCount, 12)
+	for i := range months {
+		months[i] = MonthCount{Month: i + 1, Name: time.Month(i + 1).String()}
+	}
+	for _, r := range records {
+		if r.Month < 1 || r.Month > 12 {
+			continue
+		}
+		months[r.Month-1].Count++
+		if r.Year == 0 {
+			noYear++
+		}
+	}
+	return months, noYear
+}
+
+// newBirthdaysByMonthCmd reports how many contacts have a birthday each month
+func newBirthdaysByMonthCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "birthdays-by-month",
+		Short: "Show contacts-per-birth-month histogram",
+		Long: `Count contacts by birth month, for planning monthly outreach. Contacts
+without a birthday are skipped; birthdays saved without a year are counted
+in their month and reported as year_unknown.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			records, err := fetchBirthdays()
+			if err != nil {
+				return printScriptError("stats_failed", err)
+			}
+
+			months, noYear := birthdayHistogram(records)
+
+			return output.Print(map[string]any{
+				"with_birthday": len(records),
+				"year_unknown":  noYear,
+				"months":        months,
+			})
+		},
+	}
+
+	return cmd
+}
+
+// UpcomingBirthday is a contact's next birthday within the birthdays window
+type UpcomingBirthday struct {
+	Name string `json:"name"`
+	// Birthday is YYYY-MM-DD, or --MM-DD when saved without a year
+	Birthday string `json:"birthday"`
+	Next     string `json:"next"`
+	InDays   int    `json:"in_days"`
+	// Turning is the age reached on Next, when the birth year is known
+	Turning int `json:"turning,omitempty"`
+}
+
+// newBirthdaysCmd lists birthdays coming up in the next --days days
+func newBirthdaysCmd() *cobra.Command {
+	var days int
+
+	cmd := &cobra.Command{
+		Use:   "birthdays",
+		Short: "List upcoming birthdays",
+		Long: `List contacts whose birthday falls within the next --days days (today
+included), soonest first. Contacts without a birthday are omitted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if days < 0 {
+				return output.PrintError("invalid_input", "--days must not be negative", nil)
+			}
+
+			records, err := fetchBirthdays()
+			if err != nil {
+				return printScriptError("birthdays_failed", err)
+			}
+
+			upcoming := upcomingBirthdays(records, time.Now(), days)
+
+			return output.Print(map[string]any{
+				"days":      days,
+				"count":     len(upcoming),
+				"birthdays": upcoming,
+			})
+		},
+	}
+
+	cmd.Flags().IntVarP(&days, "days", "d", 30, "Number of days ahead to look")
+
+	return cmd
+}
+
+// upcomingBirthdays returns the birthdays whose next occurrence on or after
+// now's date is at most days away, sorted by days until. Feb 29 birthdays
+// fall on Feb 28 in common years.
+func upcomingBirthdays(records []birthdayRecord, now time.Time, days int) []UpcomingBirthday {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	occurrence := func(year, month, day int) time.Time {
+		if month == 2 && day == 29 && time.Date(year, 3, 0, 0, 0, 0, 0, time.UTC).Day() != 29 {
+			day = 28
+		}
+		return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+
+	upcoming := []UpcomingBirthday{}
+	for _, r := range records {
+		if r.Month < 1 || r.Month > 12 || r.Day < 1 || r.Day > 31 {
+			continue
+		}
+		next := occurrence(today.Year(), r.Month, r.Day)
+		if next.Before(today) {
+			next = occurrence(today.Year()+1, r.Month, r.Day)
+		}
+		inDays := int(next.Sub(today).Hours() / 24)
+		if inDays > days {
+			continue
+		}
+
+		b := UpcomingBirthday{
+			Name:     r.Name,
+			Birthday: fmt.Sprintf("--%02d-%02d", r.Month, r.Day),
+			Next:     next.Format("2006-01-02"),
+			InDays:   inDays,
+		}
+		if r.Year != 0 {
+			b.Birthday = fmt.Sprintf("%04d-%02d-%02d", r.Year, r.Month, r.Day)
+			b.Turning = next.Year() - r.Year
+		}
+		upcoming = append(upcoming, b)
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		return upcoming[i].InDays < upcoming[j].InDays
+	})
+	return upcoming
+}
+
+// WatchEvent is emitted by watch when a contact is added, modified, or deleted
+type WatchEvent struct {
+	Event     string `json:"event"`
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Timestamp string `json:"timestamp"`
+}
+
+// snapshotEntry is the state of one contact used for change detection
+type snapshotEntry struct {
+	Name string
+	Hash string
+}
+
+// snapshotContacts reduces records to a map of contact ID to a hash of the
+// name and primary email/phone, which is enough to detect edits cheaply.
+func snapshotContacts(records []contactRecord) map[string]snapshotEntry {
+	snap := make(map[string]snapshotEntry, len(records))
+	for _, r := range records {
+		email, phone := "", ""
+		if len(r.Emails) > 0 {
+			email = r.Emails[0]
+		}
+		if len(r.Phones) > 0 {
+			phone = r.Phones[0]
+		}
+		sum := sha256.Sum256([]byte(r.Name + "\x00" + email + "\x00" + phone))
+		snap[r.ID] = snapshotEntry{Name: r.Name, Hash: hex.EncodeToString(sum[:])}
+	}
+	return snap
+}
+
+// diffSnapshots returns add/modify/delete events between two snapshots,
+// sorted by event type then ID for deterministic output.
+func diffSnapshots(prev, curr map[string]snapshotEntry, now time.Time) []WatchEvent {
+	ts := now.UTC().Format(time.RFC3339)
+	var events []WatchEvent
+	for id, c := range curr {
+		p, ok := prev[id]
+		switch {
+		case !ok:
+			events = append(events, WatchEvent{Event: "add", ID: id, Name: c.Name, Timestamp: ts})
+		case p.Hash != c.Hash:
+			events = append(events, WatchEvent{Event: "modify", ID: id, Name: c.Name, Timestamp: ts})
+		}
+	}
+	for id, p := range prev {
+		if _, ok := curr[id]; !ok {
+			events = append(events, WatchEvent{Event: "delete", ID: id, Name: p.Name, Timestamp: ts})
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Event != events[j].Event {
+			return events[i].Event < events[j].Event
+		}
+		return events[i].ID < events[j].ID
+	})
+	return events
+}
+
+// newWatchCmd polls the address book and streams change events
+func newWatchCmd() *cobra.Command {
+	var interval time.Duration
+	var polls int
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Poll for contact changes and emit NDJSON events",
+		Long: `Periodically snapshot the address book and emit one JSON object per line for
+every contact that was added, modified, or deleted since the previous poll.
+Apple Contacts has no push notifications, so changes are detected by diffing
+snapshots. The first poll establishes a baseline and emits nothing.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if interval <= 0 {
+				return output.PrintError("invalid_input", "--interval must be positive", nil)
+			}
+
+			records, err := fetchContactRecords()
+			if err != nil {
+				return printScriptError("watch_failed", err)
+			}
+			prev := snapshotContacts(records)
+
+			enc := json.NewEncoder(os.Stdout)
+			for i := 0; polls <= 0 || i < polls; i++ {
+				time.Sleep(interval)
+
+				records, err := fetchContactRecords()
+				if err != nil {
+					return printScriptError("watch_failed", err)
+				}
+				curr := snapshotContacts(records)
+
+				for _, ev := range diffSnapshots(prev, curr, time.Now()) {
+					if err := enc.Encode(ev); err != nil {
+						return err
+					}
+				}
+				prev = curr
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().DurationVarP(&interval, "interval", "i", 60*time.Second, "Polling interval")
+	cmd.Flags().IntVar(&polls, "polls", 0, "Stop after this many polls (0 = run until interrupted)")
+
+	return cmd
+}
+
+// exportFormats lists the serializations supported by export
+var exportFormats = []string{"csv", "json", "vcard"}
+
+// ExportFailure records a contact whose details could not be read
+type ExportFailure struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+func newExportCmd() *cobra.Command {
+	var all bool
+	var group string
+	var format string
+	var outFile string
+	var concurrency int
+
+	cmd := &cobra.Command{
+		Use:   "export [name]",
+		Short: "Export contacts with full details as CSV, JSON, or vCard",
+		Long: `Read the full details of one contact by name, every contact with --all, or
+only the members of one group with --group, and write them in the chosen
+format. --format vcard writes vCard 3.0 with one VCARD block per contact.
+Details are fetched per contact, with at most --concurrency lookups in
+flight. Without --out the export is written to stdout, and any contacts
+that could not be read are listed on stderr with a non-zero exit; with --out
+a summary including failures is printed.`,
+		Args: cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 && (all || group != "") {
+				return output.PrintError("invalid_input", "Pass a name, --all, or --group, not more than one", nil)
+			}
+			if len(args) == 0 && !all && group == "" {
+				return output.PrintError("invalid_input", "Specify a contact name, --all to export every contact, or --group to export one group", nil)
+			}
+			format = strings.ToLower(format)
+			if !containsString(exportFormats, format) {
+				return output.PrintError("invalid_input",
+					fmt.Sprintf("Unsupported format: %s", format),
+					map[string]any{"supported": exportFormats})
+			}
+
+			var contacts []Contact
+			failures := []ExportFailure{}
+			if len(args) > 0 {
+				contact, err := fetchContactDetail(fmt.Sprintf(`first person whose name is "%s"`, escapeAppleScript(args[0])))
+				if err != nil {
+					if se, ok := err.(*scriptError); ok {
+						if se.Code == "contact_not_found" {
+							return output.PrintError(se.Code,
+								fmt.Sprintf("Contact not found: %s", args[0]),
+								map[string]string{"name": args[0]})
+						}
+						return output.PrintError("export_failed", se.Message, nil)
+					}
+					return printScriptError("export_failed", err)
+				}
+				contacts = []Contact{contact}
+			} else {
+				var records []contactRecord
+				var err error
+				if group != "" {
+					records, err = fetchGroupMembers(group)
+				} else {
+					records, err = fetchContactRecords()
+				}
+				if errors.Is(err, errGroupNotFound) {
+					return output.PrintError("group_not_found",
+						fmt.Sprintf("Group not found: %s", group),
+						map[string]string{"name": group})
+				}
+				if err != nil {
+					return printScriptError("export_failed", err)
+				}
+
+				contacts, failures = fetchContactDetails(records, concurrency)
+			}
+
+			var buf bytes.Buffer
+			if err := writeContacts(&buf, contacts, format); err != nil {
+				return printScriptError("export_failed", err)
+			}
+
+			if outFile == "" || outFile == "-" {
+				if _, err := os.Stdout.Write(buf.Bytes()); err != nil {
+					return err
+				}
+				return reportExportFailures(os.Stderr, failures)
+			}
+
+			if err := os.WriteFile(outFile, buf.Bytes(), 0o600); err != nil {
+				return output.PrintError("write_failed", err.Error(), nil)
+			}
+
+			summary := map[string]any{
+				"format":   format,
+				"path":     outFile,
+				"count":    len(contacts),
+				"failed":   len(failures),
+				"failures": failures,
+			}
+			if group != "" {
+				summary["group"] = group
+			}
+			return output.Print(summary)
+		},
+	}
+
+	cmd.Flags().BoolVar(&all, "all", false, "Export every contact")
+	cmd.Flags().StringVar(&group, "group", "", "Export only the members of this group")
+	cmd.Flags().StringVarP(&format, "format", "f", "json", "Output format: csv, json, or vcard")
+	cmd.Flags().StringVar(&outFile, "out", "", "Write to this file instead of stdout")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "c", 4, "Maximum concurrent detail lookups")
+	cmd.MarkFlagsMutuallyExclusive("all", "group")
+
+	return cmd
+}
+
+// reportExportFailures writes failures to w as JSON and returns an
+// already-printed error, so a stdout export that skipped contacts exits
+// non-zero without corrupting the exported data
+func reportExportFailures(w io.Writer, failures []ExportFailure) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	enc := json.NewEncoder(w)
+	_ = enc.Encode(map[string]any{"failed": len(failures), "failures": failures})
+	return &output.PrintedError{Err: fmt.Errorf("export_incomplete: %d contacts could not be read", len(failures))}
+}
+
+// errGroupNotFound is returned by fetchGroupMembers for a missing group
+var errGroupNotFound = errors.New("group not found")
+
+// fetchGroupMembers lists the id and name of each person in a group, the
+// same membership the group command reports
+func fetchGroupMembers(group string) ([]contactRecord, error) {
+	script := fmt.Sprintf(`
+tell application "Contacts"
+	try
+		set g to group "%s"
+		set memberList to {}
+		repeat with p in people of g
+			set end of memberList to (id of p) & "|||" & (name of p)
+		end repeat
+		set AppleScript's text item delimiters to ":::"
+		return memberList as text
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, escapeAppleScript(group))
+
+	result, err := runAppleScript(script)
+	if err != nil {
+		return nil, err
+	}
+	return parseGroupMembers(result)
+}
+
+// parseGroupMembers parses fetchGroupMembers' script output
+func parseGroupMembers(result string) ([]contactRecord, error) {
+	if strings.HasPrefix(result, "ERROR:") {
+		errMsg := strings.TrimPrefix(result, "ERROR: ")
+		if strings.Contains(errMsg, "Can't get group") {
+			return nil, errGroupNotFound
+		}
+		return nil, errors.New(errMsg)
+	}
+
+	records := []contactRecord{}
+	if result == "" {
+		return records, nil
+	}
+	for _, item := range strings.Split(result, ":::") {
+		id, name, ok := strings.Cut(item, "|||")
+		if !ok || strings.TrimSpace(id) == "" {
+			continue
+		}
+		records = append(records, contactRecord{ID: strings.TrimSpace(id), Name: strings.TrimSpace(name)})
+	}
+	return records, nil
+}
+
+// containsString reports whether list contains s
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchContactDetails reads full details for each record with bounded
+// concurrency, preserving record order and skipping contacts that fail.
+func fetchContactDetails(records []contactRecord, concurrency int) ([]Contact, []ExportFailure) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]*Contact, len(records))
+	errs := make([]error, len(records))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, r := range records {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			c, err := fetchContactDetail(fmt.Sprintf(`person id "%s"`, escapeAppleScript(id)))
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = &c
+		}(i, r.ID)
+	}
+	wg.Wait()
+
+	contacts := make([]Contact, 0, len(records))
+	failures := []ExportFailure{}
+	for i, c := range results {
+		if c == nil {
+			failures = append(failures, ExportFailure{ID: records[i].ID, Name: records[i].Name, Message: errs[i].Error()})
+			continue
+		}
+		contacts = append(contacts, *c)
+	}
+	return contacts, failures
+}
+
+// writeContacts serializes contacts to w in the given export format
+func writeContacts(w io.Writer, contacts []Contact, format string) error {
+	switch format {
+	case "csv":
+		return writeContactsCSV(w, contacts)
+	case "vcard":
+		for _, c := range contacts {
+			if _, err := io.WriteString(w, formatVCard(c)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(contacts)
+	}
+}
+
+// writeContactsCSV writes one row per contact. Multi-valued fields are
+// joined with "; " and each value is prefixed with its label when present.
+func writeContactsCSV(w io.Writer, contacts []Contact) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"name", "first_name", "last_name", "company", "job_title", "emails", "phones", "addresses", "birthday", "notes"})
+	for _, c := range contacts {
+		var emails, phones, addrs []string
+		for _, e := range c.Emails {
+			emails = append(emails, labeled(e.Label, e.Value))
+		}
+		for _, p := range c.Phones {
+			phones = append(phones, labeled(p.Label, p.Value))
+		}
+		for _, a := range c.Addresses {
+			addrs = append(addrs, labeled(a.Label, joinNonEmpty(", ", a.Street, a.City, a.State, a.Zip, a.Country)))
+		}
+		_ = cw.Write([]string{
+			c.Name, c.FirstName, c.LastName, c.Company, c.JobTitle,
+			strings.Join(emails, "; "),
+			strings.Join(phones, "; "),
+			strings.Join(addrs, "; "),
+			c.Birthday, c.Notes,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// labeled prefixes value with "label: " when a label is set
+func labeled(label, value string) string {
+	if label == "" {
+		return value
+	}
+	return label + ": " + value
+}
+
+// joinNonEmpty joins the non-empty parts with sep
+func joinNonEmpty(sep string, parts ...string) string {
+	var kept []string
+	for _, p := range parts {
+		if p != "" {
+			kept = append(kept, p)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+// birthdayLayouts are the formats AppleScript uses when coercing a date to text
+var birthdayLayouts = []string{
+	"Monday, January 2, 2006 at 3:04:05 PM",
+	"Monday, January 2, 2006 3:04:05 PM",
+	"Monday, 2 January 2006 at 15:04:05",
+	"2006-01-02",
+}
+
+// vcardEscape escapes text values per RFC 6350
+func vcardEscape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+// formatVCard renders a contact as a vCard 3.0 entry
+func formatVCard(c Contact) string {
+	var b strings.Builder
+	b.WriteString("BEGIN:VCARD\r\nVERSION:3.0\r\n")
+	fmt.Fprintf(&b, "FN:%s\r\n", vcardEscape(c.Name))
+	fmt.Fprintf(&b, "N:%s;%s;;;\r\n", vcardEscape(c.LastName), vcardEscape(c.FirstName))
+	if c.Company != "" {
+		fmt.Fprintf(&b, "ORG:%s\r\n", vcardEscape(c.Company))
+	}
+	if c.JobTitle != "" {
+		fmt.Fprintf(&b, "TITLE:%s\r\n", vcardEscape(c.JobTitle))
+	}
+	for _, e := range c.Emails {
+		b.WriteString("EMAIL")
+		if label := cleanLabel(e.Label); label != "" {
+			fmt.Fprintf(&b, ";TYPE=%s", strings.ToUpper(label))
+		}
+		fmt.Fprintf(&b, ":%s\r\n", vcardEscape(e.Value))
+	}
+	for _, p := range c.Phones {
+		b.WriteString("TEL")
+		if label := cleanLabel(p.Label); label != "" {
+			fmt.Fprintf(&b, ";TYPE=%s", strings.ToUpper(label))
+		}
+		fmt.Fprintf(&b, ":%s\r\n", vcardEscape(p.Value))
+	}
+	for _, a := range c.Addresses {
+		b.WriteString("ADR")
+		if label := cleanLabel(a.Label); label != "" {
+			fmt.Fprintf(&b, ";TYPE=%s", strings.ToUpper(label))
+		}
+		fmt.Fprintf(&b, ":;;%s;%s;%s;%s;%s\r\n",
+			vcardEscape(a.Street), vcardEscape(a.City), vcardEscape(a.State),
+			vcardEscape(a.Zip), vcardEscape(a.Country))
+	}
+	if c.Birthday != "" {
+		for _, layout := range birthdayLayouts {
+			if t, err := time.Parse(layout, c.Birthday); err == nil {
+				fmt.Fprintf(&b, "BDAY:%s\r\n", t.Format("2006-01-02"))
+				break
+			}
+		}
+	}
+	if c.Notes != "" {
+		fmt.Fprintf(&b, "NOTE:%s\r\n", vcardEscape(c.Notes))
+	}
+	if len(c.Groups) > 0 {
+		categories := make([]string, len(c.Groups))
+		for i, g := range c.Groups {
+			categories[i] = vcardEscape(g)
+		}
+		fmt.Fprintf(&b, "CATEGORIES:%s\r\n", strings.Join(categories, ","))
+	}
+	b.WriteString("END:VCARD\r\n")
+	return b.String()
+}
+
+// standardLabels are the built-in Contacts labels, which are stored wrapped
+// as "_$!<Label>!$_" rather than as plain custom text
+var standardLabels = map[string]string{
+	"home":    "Home",
+	"work":    "Work",
+	"other":   "Other",
+	"mobile":  "Mobile",
+	"main":    "Main",
+	"iphone":  "iPhone",
+	"homefax": "HomeFAX",
+	"workfax": "WorkFAX",
+	"pager":   "Pager",
+}
+
+// appleLabel converts a display label into the form Contacts stores,
+// wrapping standard labels so they localize and sort like built-in ones
+func appleLabel(label string) string {
+	if std, ok := standardLabels[strings.ToLower(label)]; ok {
+		return "_$!<" + std + ">!$_"
+	}
+	return label
+}
+
+func newRelabelCmd() *cobra.Command {
+	var mapping map[string]string
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "relabel [name]",
+		Short: "Rewrite email and phone labels using a mapping",
+		Long: `Rewrite the labels of a contact's emails and phones, e.g.
+  --map work=Work,cell=Mobile,office=Work
+Matching ignores case and the internal "_$!<...>!$_" wrapping, so a
+case-only mapping like work=Work rewrites "work" labels. Use --all instead
+of a name to normalize labels across the whole address book; it may run for
+several minutes on large address books.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(mapping) == 0 {
+				return output.PrintError("invalid_input", "Provide --map from=to pairs", nil)
+			}
+			if all == (len(args) == 1) {
+				return output.PrintError("invalid_input", "Specify either a contact name or --all", nil)
+			}
+
+			target := "people"
+			if !all {
+				target = fmt.Sprintf(`{first person whose name is "%s"}`, escapeAppleScript(args[0]))
+			}
+
+			timeout := appleScriptTimeout
+			if all {
+				timeout = bulkScriptTimeout
+			}
+			result, err := runOsascriptTimeout("AppleScript", relabelScript(target, mapping), timeout)
+			if err != nil {
+				return printScriptError("relabel_failed", err)
+			}
+			if strings.HasPrefix(result, "ERROR:") {
+				errMsg := strings.TrimPrefix(result, "ERROR: ")
+				if !all && strings.Contains(errMsg, "Can't get person") {
+					return output.PrintError("contact_not_found",
+						fmt.Sprintf("Contact not found: %s", args[0]),
+						map[string]string{"name": args[0]})
+				}
+				return output.PrintError("relabel_failed", errMsg, nil)
+			}
+
+			changed := 0
+			_, _ = fmt.Sscanf(result, "%d", &changed)
+
+			response := map[string]any{
+				"changed": changed,
+				"map":     mapping,
+			}
+			if all {
+				response["scope"] = "all"
+			} else {
+				response["name"] = args[0]
+			}
+			return output.Print(response)
+		},
+	}
+
+	cmd.Flags().StringToStringVar(&mapping, "map", nil, "Label mapping as from=to pairs, e.g. work=Work,cell=Mobile")
+	cmd.Flags().BoolVar(&all, "all", false, "Relabel every contact in the address book")
+
+	return cmd
+}
+
+// relabelScript builds an AppleScript that rewrites email and phone labels
+// of the target people and returns the number of labels changed. Keys are
+// sorted so the generated script is deterministic.
+func relabelScript(target string, mapping map[string]string) string {
+	keys := make([]string, 0, len(mapping))
+	for k := range mapping {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	from := make([]string, len(keys))
+	to := make([]string, len(keys))
+	for i, k := range keys {
+		from[i] = `"` + escapeAppleScript(cleanLabel(k)) + `"`
+		to[i] = `"` + escapeAppleScript(appleLabel(mapping[k])) + `"`
+	}
+
+	// AppleScript string comparison ignores case by default, which is what
+	// matching the from label wants. Deciding whether the label already
+	// equals the target must consider case, or work=Work would be skipped.
+	return fmt.Sprintf(`
+on cleanLabel(lbl)
+	if lbl starts with "_$!<" and lbl ends with ">!$_" then
+		return text 5 thru -5 of lbl
+	end if
+	return lbl
+end cleanLabel
+
+on relabel(items_, fromLabels, toLabels)
+	set changed to 0
+	repeat with itm in items_
+		set lbl to my cleanLabel(label of itm)
+		repeat with i from 1 to count of fromLabels
+			if lbl is item i of fromLabels then
+				considering case
+					set differs to (label of itm is not item i of toLabels)
+				end considering
+				if differs then
+					set label of itm to item i of toLabels
+					set changed to changed + 1
+				end if
+				exit repeat
+			end if
+		end repeat
+	end repeat
+	return changed
+end relabel
+
+tell application "Contacts"
+	try
+		set fromLabels to {%s}
+		set toLabels to {%s}
+		set changed to 0
+		repeat with p in %s
+			set changed to changed + (my relabel(emails of p, fromLabels, toLabels))
+			set changed to changed + (my relabel(phones of p, fromLabels, toLabels))
+		end repeat
+		save
+		return changed as string
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, strings.Join(from, ", "), strings.Join(to, ", "), target)
+}
+
+func newSetPhotoCmd() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "set-photo [name]",
+		Short: "Set a contact's photo from an image file",
+		Long:  `Set the photo of the first contact with the given name from a JPEG, PNG, TIFF or GIF file.`,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			path, err := filepath.Abs(file)
+			if err != nil {
+				return output.PrintError("invalid_input", err.Error(), nil)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return output.PrintError("read_failed", err.Error(), nil)
+			}
+			format, ok := imageFormat(data)
+			if !ok {
+				return output.PrintError("unsupported_format",
+					fmt.Sprintf("Unsupported image format: %s", filepath.Base(path)),
+					map[string]any{"supported": []string{"jpeg", "png", "tiff", "gif"}})
+			}
+
+			result, err := runAppleScript(setPhotoScript(name, path, format))
+			if err != nil {
+				return printScriptError("set_photo_failed", err)
+			}
+			if strings.HasPrefix(result, "ERROR:") {
+				errMsg := strings.TrimPrefix(result, "ERROR: ")
+				if strings.Contains(errMsg, "Can't get person") {
+					return output.PrintError("contact_not_found",
+						fmt.Sprintf("Contact not found: %s", name),
+						map[string]string{"name": name})
+				}
+				return output.PrintError("set_photo_failed", errMsg, nil)
+			}
+
+			return output.Print(map[string]any{
+				"success": true,
+				"name":    result,
+				"file":    path,
+				"format":  format,
+				"bytes":   len(data),
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Image file to use as the photo (required)")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// imageClasses maps supported image formats to the AppleScript class used to
+// read the file's bytes as picture data
+var imageClasses = map[string]string{
+	"jpeg": "JPEG picture",
+	"png":  "«class PNGf»",
+	"tiff": "TIFF picture",
+	"gif":  "GIF picture",
+}
+
+// imageFormat identifies an image by its magic bytes rather than its file
+// extension, which may be missing or wrong
+func imageFormat(data []byte) (string, bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return "jpeg", true
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return "png", true
+	case bytes.HasPrefix(data, []byte("II*\x00")), bytes.HasPrefix(data, []byte("MM\x00*")):
+		return "tiff", true
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return "gif", true
+	}
+	return "", false
+}
+
+// setPhotoScript builds the AppleScript that reads path as format and sets it
+// as the image of the first person named name, returning the person's name
+func setPhotoScript(name, path, format string) string {
+	return fmt.Sprintf(`set imageData to read (POSIX file "%s") as %s
+tell application "Contacts"
+	try
+		set p to first person whose name is "%s"
+		set image of p to imageData
+		save
+		return name of p
+	on error errMsg
+		return "ERROR: " & errMsg
+	end try
+end tell`, escapeAppleScript(path), imageClasses[format], escapeAppleScript(name))
+}
@@ -1,8 +1,13 @@
 package contacts
 
 import (
+	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/unstablemind/pocket/pkg/output"
 )
 
 func TestNewCmd(t *testing.T) {
@@ -27,7 +32,7 @@ func TestNewCmd(t *testing.T) {
 	for _, s := range cmd.Commands() {
 		subs[s.Use] = true
 	}
-	for _, name := range []string{"list", "search [query]", "get [name]", "groups", "group [name]", "create [name]"} {
+	for _, name := range []string{"list", "search [query]", "get [name]", "details [name...]", "groups", "group [name]", "add-to-group [contact-name] [group-name]", "create-group [name]", "create [name]", "update [name]", "delete [name]", "dedupe", "stats", "birthdays", "watch", "export [name]", "relabel [name]", "set-photo [name]"} {
 		if !subs[name] {
 			t.Errorf("missing subcommand %q", name)
 		}
@@ -160,9 +165,29 @@ func TestNewSearchCmd(t *testing.T) {
 	}
 
 	// Check flags
-	limitFlag := cmd.Flags().Lookup("limit")
-	if limitFlag == nil {
-		t.Error("expected 'limit' flag")
+	for _, flagName := range []string{"limit", "sort-by-relevance"} {
+		if cmd.Flags().Lookup(flagName) == nil {
+			t.Errorf("expected %q flag", flagName)
+		}
+	}
+}
+
+func TestSortByRelevanceScore(t *testing.T) {
+	contacts := []ContactSummary{
+		{Name: "Alice Jones", Email: "asmith@example.com"},
+		{Name: "John Smith"},
+		{Name: "Bob Lee", Company: "Smith & Co"},
+		{Name: "Smithers Burns"},
+		{Name: "Smith"},
+	}
+
+	sortByRelevanceScore(contacts, "smith")
+
+	want := []string{"Smith", "Smithers Burns", "John Smith", "Bob Lee", "Alice Jones"}
+	for i, name := range want {
+		if contacts[i].Name != name {
+			t.Errorf("position %d: got %q, want %q", i, contacts[i].Name, name)
+		}
 	}
 }
 
@@ -200,7 +225,7 @@ func TestNewCreateCmd(t *testing.T) {
 	}
 
 	// Check flags
-	flags := []string{"email", "phone", "company", "note"}
+	flags := []string{"email", "phone", "company", "note", "group", "create-group", "stdin", "address", "address-label"}
 	for _, flagName := range flags {
 		flag := cmd.Flags().Lookup(flagName)
 		if flag == nil {
@@ -209,6 +234,197 @@ func TestNewCreateCmd(t *testing.T) {
 	}
 }
 
+func TestDecodeContactJSON(t *testing.T) {
+	input := `{"name":"Jane Doe","company":"Acme","birthday":"1990-01-02",
+		"emails":[{"label":"work","value":"jane@acme.com"},{"value":"jane@home.com"}],
+		"phones":[{"label":"mobile","value":"555-1234"}],
+		"addresses":[{"street":"1 Main St","city":"Springfield"}],
+		"groups":["Buyers"]}`
+
+	c, err := decodeContactJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("decodeContactJSON failed: %v", err)
+	}
+	if len(c.Emails) != 2 || len(c.Addresses) != 1 {
+		t.Errorf("unexpected contact: %+v", c)
+	}
+
+	applied, ignored := appliedContactFields(c)
+	if want := "name,company,emails,phones,addresses"; strings.Join(applied, ",") != want {
+		t.Errorf("applied = %v, want %s", applied, want)
+	}
+	if strings.Join(ignored, ",") != "birthday,groups" {
+		t.Errorf("ignored = %v, want [birthday groups]", ignored)
+	}
+
+	for _, bad := range []string{
+		`{"company":"Acme"}`,
+		`{"name":"X","emails":[{"label":"work"}]}`,
+		`{"name":"X","nickname":"Y"}`,
+		`not json`,
+	} {
+		if _, err := decodeContactJSON(strings.NewReader(bad)); err == nil {
+			t.Errorf("expected error for %s", bad)
+		}
+	}
+}
+
+func TestCreateContactScript(t *testing.T) {
+	script := createContactScript(Contact{
+		Name:      "Jane Q Doe",
+		Emails:    []Email{{Value: "a@x.com"}, {Label: "home", Value: "b@x.com"}},
+		Addresses: []Address{{City: "Springfield"}},
+	}, "", false)
+
+	for _, want := range []string{
+		`first name:"Jane", last name:"Q Doe"`,
+		`{label:"home", value:"a@x.com"}`,
+		`{label:"home", value:"b@x.com"}`,
+		`city:"Springfield"`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+	if strings.Contains(script, "targetGroup") {
+		t.Error("script should not reference a group when none is given")
+	}
+}
+
+func TestUpdateContactScript(t *testing.T) {
+	company, email := "", "jane@new.com"
+	script := updateContactScript(`Jane "JD" Doe`, contactUpdate{Company: &company, Email: &email})
+
+	for _, want := range []string{
+		`first person whose name is "Jane \"JD\" Doe"`,
+		`set organization of p to ""`,
+		`set value of first email of p to "jane@new.com"`,
+		`{label:"work", value:"jane@new.com"}`,
+		"save",
+		"return id of p",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+	for _, unwanted := range []string{"first name of p", "last name of p", "note of p", "phones of p"} {
+		if strings.Contains(script, unwanted) {
+			t.Errorf("script should not touch %q", unwanted)
+		}
+	}
+}
+
+func TestDeleteCmdRequiresYes(t *testing.T) {
+	cmd := newDeleteCmd()
+	cmd.SetArgs([]string{"Jane Doe"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected delete without --yes to fail")
+	}
+}
+
+func TestParseDeleteMatches(t *testing.T) {
+	got := parseDeleteMatches("Jane Doe|||jane@a.com|||555-0100|||Acme:::Jane Doe|||||||||")
+	if len(got) != 2 {
+		t.Fatalf("matches = %+v, want 2", got)
+	}
+	if got[0].Email != "jane@a.com" || got[0].Company != "Acme" || got[1].Name != "Jane Doe" || got[1].Email != "" {
+		t.Errorf("matches = %+v", got)
+	}
+}
+
+func TestFuzzyNameMatches(t *testing.T) {
+	names := []string{"Bob Smith", "Alice Jones", "bobby tables", "Bob Smith", ""}
+
+	if got := fuzzyNameMatches(names, "alice"); len(got) != 1 || got[0] != "Alice Jones" {
+		t.Errorf("alice = %v, want [Alice Jones]", got)
+	}
+	if got := fuzzyNameMatches(names, "Bob"); len(got) != 2 || got[0] != "Bob Smith" || got[1] != "bobby tables" {
+		t.Errorf("bob = %v, want [Bob Smith bobby tables]", got)
+	}
+	if got := fuzzyNameMatches(names, "carol"); len(got) != 0 {
+		t.Errorf("carol = %v, want none", got)
+	}
+	if got := fuzzyNameMatches(names, " "); len(got) != 0 {
+		t.Errorf("blank query = %v, want none", got)
+	}
+}
+
+func TestParseLabeledValue(t *testing.T) {
+	tests := []struct {
+		spec      string
+		wantLabel string
+		wantValue string
+	}{
+		{"me@x.com", "home", "me@x.com"},
+		{"work:me@co.com", "work", "me@co.com"},
+		{"Home Fax: 555-0100", "Home Fax", "555-0100"},
+		{"+1 555 0100", "home", "+1 555 0100"},
+		{"x123:555-0100", "home", "x123:555-0100"},
+		{":me@x.com", "home", ":me@x.com"},
+		{"work:", "work", ""},
+	}
+	for _, tt := range tests {
+		label, value := parseLabeledValue(tt.spec, "home")
+		if label != tt.wantLabel || value != tt.wantValue {
+			t.Errorf("parseLabeledValue(%q) = %q, %q; want %q, %q", tt.spec, label, value, tt.wantLabel, tt.wantValue)
+		}
+	}
+}
+
+func TestAddToGroupScript(t *testing.T) {
+	script := addToGroupScript(`Jane "JD" Doe`, "Buyers")
+	for _, want := range []string{
+		`first person whose name is "Jane \"JD\" Doe"`,
+		`set g to group "Buyers"`,
+		`return "CONTACT_NOT_FOUND"`,
+		`return "GROUP_NOT_FOUND"`,
+		"add p to g",
+		"save",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestCreateGroupScript(t *testing.T) {
+	script := createGroupScript(`VIP "A" List`)
+	for _, want := range []string{
+		`if exists group "VIP \"A\" List" then return "GROUP_EXISTS"`,
+		`make new group with properties {name:"VIP \"A\" List"}`,
+		"save",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestParseAddressSpec(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Address
+		wantErr bool
+	}{
+		{"1 Main St|Springfield|IL|62701|USA", Address{Label: "work", Street: "1 Main St", City: "Springfield", State: "IL", Zip: "62701", Country: "USA"}, false},
+		{"1 Main St | Springfield", Address{Label: "work", Street: "1 Main St", City: "Springfield"}, false},
+		{"||", Address{}, true},
+		{"a|b|c|d|e|f", Address{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseAddressSpec(tt.spec, "work")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseAddressSpec(%q) err = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("parseAddressSpec(%q) = %+v, want %+v", tt.spec, got, tt.want)
+		}
+	}
+}
+
 func TestContactStructs(t *testing.T) {
 	// Test Contact struct
 	contact := Contact{
@@ -323,3 +539,652 @@ func TestEscapeAppleScript_LongString(t *testing.T) {
 		t.Error("Not all special characters were escaped in long string")
 	}
 }
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"(555) 123-4567", "5551234567"},
+		{"+1 555 123 4567", "5551234567"},
+		{"555.123.4567", "5551234567"},
+		{"123", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got := normalizePhone(tt.input)
+		if got != tt.want {
+			t.Errorf("normalizePhone(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestIsPermissionDenied(t *testing.T) {
+	if !IsPermissionDenied("execution error: Not authorized to send Apple events to Contacts. (-1743)") {
+		t.Error("expected TCC error to be detected")
+	}
+	if IsPermissionDenied("syntax error") {
+		t.Error("unexpected permission match for unrelated error")
+	}
+}
+
+func TestContactNames(t *testing.T) {
+	names := contactNames([]ContactSummary{{Name: "Ann", Email: "a@x.com"}, {Name: "Bob"}})
+	if len(names) != 2 || names[0] != "Ann" || names[1] != "Bob" {
+		t.Errorf("contactNames = %v", names)
+	}
+	if names := contactNames(nil); names == nil || len(names) != 0 {
+		t.Errorf("contactNames(nil) = %#v, want empty slice", names)
+	}
+}
+
+func TestPhoneQueryDigits(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"5551234567", "5551234567"},
+		{"+1 (555) 123-4567", "5551234567"},
+		{"555-1234", "5551234"},
+		{"4567", ""},
+		{"John 5551234567", ""},
+	}
+	for _, tt := range tests {
+		if got := phoneQueryDigits(tt.input); got != tt.want {
+			t.Errorf("phoneQueryDigits(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicateClusters(t *testing.T) {
+	records := []contactRecord{
+		{ID: "1", Name: "John Smith", Emails: []string{"john@example.com"}},
+		{ID: "2", Name: "Jane Doe", Phones: []string{"555-123-4567"}},
+		{ID: "3", Name: "J. Smith", Emails: []string{"JOHN@example.com"}, Phones: []string{"555 999 0000"}},
+		{ID: "4", Name: "Bob"},
+		{ID: "5", Name: "Janie", Phones: []string{"+1 (555) 123-4567"}},
+		{ID: "6", Name: "Johnny", Phones: []string{"5559990000"}},
+	}
+
+	clusters := findDuplicateClusters(records)
+	if len(clusters) != 2 {
+		t.Fatalf("expected 2 clusters, got %d: %v", len(clusters), clusters)
+	}
+	if len(clusters[0]) != 3 || clusters[0][0] != 0 || clusters[0][1] != 2 || clusters[0][2] != 5 {
+		t.Errorf("unexpected first cluster: %v", clusters[0])
+	}
+	if len(clusters[1]) != 2 || clusters[1][0] != 1 || clusters[1][1] != 4 {
+		t.Errorf("unexpected second cluster: %v", clusters[1])
+	}
+}
+
+func TestPickSurvivor(t *testing.T) {
+	records := []contactRecord{
+		{ID: "1", Created: "2022-01-01T00:00:00.000Z", Emails: []string{"a@x.com"}},
+		{ID: "2", Created: "2020-01-01T00:00:00.000Z"},
+		{ID: "3", Created: "2024-01-01T00:00:00.000Z", Emails: []string{"a@x.com", "b@x.com"}, Company: "Acme"},
+	}
+	cluster := []int{0, 1, 2}
+
+	if got := pickSurvivor(records, cluster, "oldest"); got != 1 {
+		t.Errorf("oldest: got %d, want 1", got)
+	}
+	if got := pickSurvivor(records, cluster, "newest"); got != 2 {
+		t.Errorf("newest: got %d, want 2", got)
+	}
+	if got := pickSurvivor(records, cluster, "most-fields"); got != 2 {
+		t.Errorf("most-fields: got %d, want 2", got)
+	}
+}
+
+func TestPlanMerge(t *testing.T) {
+	survivor := contactRecord{ID: "1", Emails: []string{"a@x.com"}, Phones: []string{"555-123-4567"}}
+	dups := []contactRecord{
+		{ID: "2", Emails: []string{"A@x.com", "b@x.com"}, Phones: []string{"(555) 123-4567"}, Company: "Acme"},
+		{ID: "3", Phones: []string{"555-999-0000"}, JobTitle: "CEO"},
+	}
+
+	plan := planMerge(survivor, dups, nil)
+	if len(plan.AddEmails) != 1 || plan.AddEmails[0].Value != "b@x.com" {
+		t.Errorf("AddEmails = %v, want [b@x.com]", plan.AddEmails)
+	}
+	if len(plan.AddPhones) != 1 || plan.AddPhones[0].Value != "555-999-0000" {
+		t.Errorf("AddPhones = %v, want [555-999-0000]", plan.AddPhones)
+	}
+	if plan.SetCompany != "Acme" {
+		t.Errorf("SetCompany = %q, want Acme", plan.SetCompany)
+	}
+	if plan.SetJobTitle != "CEO" {
+		t.Errorf("SetJobTitle = %q, want CEO", plan.SetJobTitle)
+	}
+
+	script := mergeScript(plan)
+	if !strings.Contains(script, `delete person id "2"`) || !strings.Contains(script, `delete person id "3"`) {
+		t.Error("merge script should delete both duplicates")
+	}
+}
+
+func TestPlanMergeCarriesLabeledFields(t *testing.T) {
+	survivor := contactRecord{ID: "1", Emails: []string{"a@x.com"}}
+	dups := []contactRecord{
+		{ID: "2", Emails: []string{"b@x.com"}},
+		{ID: "3", Emails: []string{"a@x.com"}},
+		{ID: "4", Emails: []string{"a@x.com"}},
+	}
+	details := map[string]mergeDetail{
+		"1": {Emails: []Email{{Label: "_$!<Home>!$_", Value: "a@x.com"}}, Note: "met at open house"},
+		"2": {
+			Emails:    []Email{{Label: "_$!<Work>!$_", Value: "b@x.com"}},
+			Addresses: []Address{{Label: "_$!<Home>!$_", Street: "1 Main St", City: "Springfield"}},
+			URLs:      []contactURL{{Label: "homepage", Value: "https://b.example"}},
+			Note:      "met at open house",
+			Birthday:  "1980-05-02T00:00:00.000Z",
+		},
+		"3": {Emails: []Email{{Value: "a@x.com"}}, Note: "prefers text"},
+		"4": {Emails: []Email{{Value: "a@x.com"}}, Other: []string{"social_profiles"}},
+	}
+
+	plan := planMerge(survivor, dups, details)
+	if len(plan.Duplicates) != 1 || plan.Duplicates[0].ID != "2" {
+		t.Fatalf("Duplicates = %+v, want only 2", plan.Duplicates)
+	}
+	if len(plan.NeedsReview) != 2 ||
+		plan.NeedsReview[0].ID != "3" || plan.NeedsReview[0].Conflicts[0] != "notes" ||
+		plan.NeedsReview[1].ID != "4" || plan.NeedsReview[1].Conflicts[0] != "social_profiles" {
+		t.Errorf("NeedsReview = %+v", plan.NeedsReview)
+	}
+	if len(plan.AddAddresses) != 1 || len(plan.AddURLs) != 1 {
+		t.Errorf("addresses = %v, urls = %v", plan.AddAddresses, plan.AddURLs)
+	}
+	if plan.NoteFrom != "" || plan.BirthdayFrom != "2" {
+		t.Errorf("NoteFrom = %q, BirthdayFrom = %q", plan.NoteFrom, plan.BirthdayFrom)
+	}
+
+	script := mergeScript(plan)
+	for _, want := range []string{
+		`{label:"_$!<Work>!$_", value:"b@x.com"}`,
+		`{label:"_$!<Home>!$_", street:"1 Main St", city:"Springfield"`,
+		`{label:"homepage", value:"https://b.example"}`,
+		`set birth date of p to birth date of person id "2"`,
+		`delete person id "2"`,
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("merge script missing %s", want)
+		}
+	}
+	for _, id := range []string{"3", "4"} {
+		if strings.Contains(script, `delete person id "`+id+`"`) {
+			t.Errorf("duplicate %s needs review and must not be deleted", id)
+		}
+	}
+}
+
+func TestFilterContactFields(t *testing.T) {
+	c := Contact{
+		Name:    "John Doe",
+		Company: "Acme",
+		Notes:   "very long notes",
+		Emails:  []Email{{Value: "john@acme.com"}},
+		Phones:  []Phone{{Value: "555-1234"}},
+	}
+
+	got := filterContactFields(c, []string{"phones"}, nil)
+	if got.Name != "John Doe" || len(got.Phones) != 1 {
+		t.Error("include should keep name and selected fields")
+	}
+	if got.Notes != "" || got.Company != "" || len(got.Emails) != 0 {
+		t.Error("include should drop unselected fields")
+	}
+
+	got = filterContactFields(c, nil, []string{"notes"})
+	if got.Notes != "" {
+		t.Error("exclude should drop notes")
+	}
+	if got.Company != "Acme" || len(got.Emails) != 1 {
+		t.Error("exclude should keep other fields")
+	}
+
+	if err := validateContactFields([]string{"emails", "bogus"}); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestExplicitContact(t *testing.T) {
+	c := Contact{Name: "Jane", Emails: []Email{{Value: "jane@example.com"}}}
+
+	data, err := json.Marshal(explicitContact(c, selectedContactFields(nil, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range append([]string{"name"}, contactFieldNames...) {
+		if _, ok := got[key]; !ok {
+			t.Errorf("missing key %q", key)
+		}
+	}
+	if phones, ok := got["phones"].([]any); !ok || len(phones) != 0 {
+		t.Errorf("phones = %v, want empty array", got["phones"])
+	}
+	email := got["emails"].([]any)[0].(map[string]any)
+	if _, ok := email["label"]; !ok {
+		t.Error("expected empty label key on email")
+	}
+
+	filtered := explicitContact(c, selectedContactFields([]string{"emails"}, nil))
+	if _, ok := filtered["notes"]; ok {
+		t.Error("unselected fields should stay omitted")
+	}
+	if _, ok := filtered["emails"]; !ok {
+		t.Error("selected field missing")
+	}
+}
+
+func TestCompanyHistogram(t *testing.T) {
+	orgs := []string{"Acme", "", "Globex", "acme", "null", "Initech", "Globex", "ACME "}
+
+	counts, noCompany := companyHistogram(orgs)
+	if noCompany != 2 {
+		t.Errorf("noCompany = %d, want 2", noCompany)
+	}
+	want := []CompanyCount{{"Acme", 3}, {"Globex", 2}, {"Initech", 1}}
+	if len(counts) != len(want) {
+		t.Fatalf("got %d companies, want %d: %v", len(counts), len(want), counts)
+	}
+	for i := range want {
+		if counts[i] != want[i] {
+			t.Errorf("position %d: got %v, want %v", i, counts[i], want[i])
+		}
+	}
+}
+
+func TestBirthdayHistogram(t *testing.T) {
+	records := []birthdayRecord{
+		{Name: "A", Month: 3, Day: 14, Year: 1980},
+		{Name: "B", Month: 3, Day: 2},
+		{Name: "C", Month: 12, Day: 25, Year: 1990},
+		{Name: "D", Month: 0},
+	}
+
+	months, noYear := birthdayHistogram(records)
+	if len(months) != 12 {
+		t.Fatalf("got %d months, want 12", len(months))
+	}
+	if noYear != 1 {
+		t.Errorf("noYear = %d, want 1", noYear)
+	}
+	if months[2].Count != 2 || months[2].Name != "March" {
+		t.Errorf("March = %+v, want count 2", months[2])
+	}
+	if months[11].Count != 1 {
+		t.Errorf("December count = %d, want 1", months[11].Count)
+	}
+	if months[0].Count != 0 {
+		t.Errorf("January count = %d, want 0", months[0].Count)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	prev := snapshotContacts([]contactRecord{
+		{ID: "1", Name: "Alice", Emails: []string{"a@x.com"}},
+		{ID: "2", Name: "Bob"},
+		{ID: "3", Name: "Carol", Phones: []string{"555"}},
+	})
+	curr := snapshotContacts([]contactRecord{
+		{ID: "1", Name: "Alice", Emails: []string{"a@x.com"}},
+		{ID: "3", Name: "Carol", Phones: []string{"556"}},
+		{ID: "4", Name: "Dave"},
+	})
+
+	events := diffSnapshots(prev, curr, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	want := []struct{ event, id string }{
+		{"add", "4"},
+		{"delete", "2"},
+		{"modify", "3"},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, w := range want {
+		if events[i].Event != w.event || events[i].ID != w.id {
+			t.Errorf("event %d = %s/%s, want %s/%s", i, events[i].Event, events[i].ID, w.event, w.id)
+		}
+	}
+	if events[0].Timestamp != "2024-01-01T00:00:00Z" {
+		t.Errorf("unexpected timestamp %q", events[0].Timestamp)
+	}
+}
+
+func TestParseContactDetail(t *testing.T) {
+	raw := "Jane Doe|||Jane|||Doe|||Acme|||CEO|||Met at expo|||Monday, January 15, 1990 at 12:00:00 AM|||" +
+		"_$!<Work>!$_=jane@acme.com;;;home=jane@example.com;;;|||" +
+		"_$!<Mobile>!$_=555-0100;;;|||" +
+		"_$!<Home>!$_=1 Main St|Springfield|IL|62701|USA;;;"
+
+	c, err := parseContactDetail(raw)
+	if err != nil {
+		t.Fatalf("parseContactDetail failed: %v", err)
+	}
+	if c.Name != "Jane Doe" || c.Company != "Acme" || c.JobTitle != "CEO" {
+		t.Errorf("unexpected basic fields: %+v", c)
+	}
+	if len(c.Emails) != 2 || c.Emails[0].Label != "Work" || c.Emails[1].Value != "jane@example.com" {
+		t.Errorf("unexpected emails: %+v", c.Emails)
+	}
+	if len(c.Phones) != 1 || c.Phones[0].Label != "Mobile" {
+		t.Errorf("unexpected phones: %+v", c.Phones)
+	}
+	if len(c.Addresses) != 1 || c.Addresses[0].City != "Springfield" {
+		t.Errorf("unexpected addresses: %+v", c.Addresses)
+	}
+
+	if _, err := parseContactDetail("too|||short"); err == nil {
+		t.Error("expected error for truncated output, got nil")
+	}
+}
+
+func TestFormatVCard(t *testing.T) {
+	c := Contact{
+		Name:      "Jane Doe",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Company:   "Acme, Inc.",
+		Emails:    []Email{{Label: "Work", Value: "jane@acme.com"}},
+		Phones:    []Phone{{Value: "555-0100"}, {Label: "_$!<Mobile>!$_", Value: "555-0199"}},
+		Addresses: []Address{{Label: "Home", Street: "1 Main St", City: "Springfield", Zip: "62701"}},
+		Birthday:  "Monday, January 15, 1990 at 12:00:00 AM",
+		Notes:     "line one\nline two",
+	}
+	got := formatVCard(c)
+	for _, want := range []string{
+		"BEGIN:VCARD\r\nVERSION:3.0\r\n",
+		"FN:Jane Doe\r\n",
+		"N:Doe;Jane;;;\r\n",
+		"ORG:Acme\\, Inc.\r\n",
+		"EMAIL;TYPE=WORK:jane@acme.com\r\n",
+		"TEL:555-0100\r\n",
+		"TEL;TYPE=MOBILE:555-0199\r\n",
+		"ADR;TYPE=HOME:;;1 Main St;Springfield;;62701;\r\n",
+		"BDAY:1990-01-15\r\n",
+		"NOTE:line one\\nline two\r\n",
+		"END:VCARD\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("vCard missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func TestWriteContactsCSV(t *testing.T) {
+	contacts := []Contact{{
+		Name:   "Jane Doe",
+		Emails: []Email{{Label: "work", Value: "jane@acme.com"}, {Value: "jd@example.com"}},
+	}}
+	var b strings.Builder
+	if err := writeContactsCSV(&b, contacts); err != nil {
+		t.Fatalf("writeContactsCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected header and one row, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "name,first_name,") {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "work: jane@acme.com; jd@example.com") {
+		t.Errorf("unexpected row: %s", lines[1])
+	}
+}
+
+func TestFindIdentityMatch(t *testing.T) {
+	records := []contactRecord{
+		{ID: "1", Name: "Jane", Emails: []string{"Jane@Example.com"}},
+		{ID: "2", Name: "Bob", Phones: []string{"+1 (555) 010-0200"}},
+	}
+
+	if m := findIdentityMatch(records, " jane@example.COM ", ""); m == nil || m.ID != "1" {
+		t.Errorf("expected email match on record 1, got %+v", m)
+	}
+	if m := findIdentityMatch(records, "", "555-010-0200"); m == nil || m.ID != "2" {
+		t.Errorf("expected phone match on record 2, got %+v", m)
+	}
+	if m := findIdentityMatch(records, "other@example.com", "555-999-0000"); m != nil {
+		t.Errorf("expected no match, got %+v", m)
+	}
+}
+
+func TestFilterRecordsByDateModified(t *testing.T) {
+	records := []contactRecord{
+		{Name: "Old", Modified: "2024-01-01T10:00:00.000Z"},
+		{Name: "Newer", Modified: "2024-03-01T10:00:00.000Z", Emails: []string{"n@example.com"}},
+		{Name: "Newest", Modified: "2024-04-01T10:00:00.000Z"},
+		{Name: "Unknown"},
+	}
+	cutoff, err := parseSinceDate("2024-02-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("parseSinceDate failed: %v", err)
+	}
+
+	got := filterRecordsByDate(records, dateFilter{modifiedAfter: cutoff})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 contacts, got %d", len(got))
+	}
+	if got[0].Name != "Newest" || got[1].Name != "Newer" {
+		t.Errorf("expected most recent first, got %q, %q", got[0].Name, got[1].Name)
+	}
+	if got[1].Email != "n@example.com" || got[1].Modified == "" {
+		t.Errorf("unexpected summary: %+v", got[1])
+	}
+
+	if _, err := parseSinceDate("last week"); err == nil {
+		t.Error("expected error for unparseable date, got nil")
+	}
+}
+
+func TestFilterRecordsByDateCreated(t *testing.T) {
+	records := []contactRecord{
+		{Name: "Early", Created: "2024-01-01T10:00:00.000Z", Modified: "2024-05-01T10:00:00.000Z"},
+		{Name: "Recent", Created: "2024-03-01T10:00:00.000Z", Modified: "2024-03-02T10:00:00.000Z"},
+		{Name: "Latest", Created: "2024-04-01T10:00:00.000Z", Modified: "2024-01-15T10:00:00.000Z"},
+		{Name: "Undated"},
+	}
+	cutoff, _ := parseSinceDate("2024-02-01T00:00:00Z")
+
+	got := filterRecordsByDate(records, dateFilter{createdAfter: cutoff})
+	if len(got) != 2 || got[0].Name != "Latest" || got[1].Name != "Recent" {
+		t.Fatalf("created-after = %+v, want Latest, Recent", got)
+	}
+	if got[0].Created == "" || got[0].Modified != "" {
+		t.Errorf("expected only the created timestamp, got %+v", got[0])
+	}
+
+	got = filterRecordsByDate(records, dateFilter{createdAfter: cutoff, modifiedAfter: cutoff})
+	if len(got) != 1 || got[0].Name != "Recent" {
+		t.Errorf("both filters = %+v, want Recent", got)
+	}
+}
+
+func TestAppleLabel(t *testing.T) {
+	tests := map[string]string{
+		"work":   "_$!<Work>!$_",
+		"Mobile": "_$!<Mobile>!$_",
+		"iphone": "_$!<iPhone>!$_",
+		"Office": "Office",
+	}
+	for in, want := range tests {
+		if got := appleLabel(in); got != want {
+			t.Errorf("appleLabel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRelabelScript(t *testing.T) {
+	script := relabelScript("people", map[string]string{"cell": "Mobile", "_$!<Work>!$_": "Office"})
+	for _, want := range []string{
+		`set fromLabels to {"Work", "cell"}`,
+		`set toLabels to {"Office", "_$!<Mobile>!$_"}`,
+		"repeat with p in people",
+		"considering case",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q:\n%s", want, script)
+		}
+	}
+}
+
+func TestImageFormat(t *testing.T) {
+	tests := []struct {
+		data []byte
+		want string
+		ok   bool
+	}{
+		{[]byte{0xFF, 0xD8, 0xFF, 0xE0}, "jpeg", true},
+		{[]byte("\x89PNG\r\n\x1a\nrest"), "png", true},
+		{[]byte("II*\x00rest"), "tiff", true},
+		{[]byte("GIF89a..."), "gif", true},
+		{[]byte("RIFF....WEBP"), "", false},
+		{nil, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := imageFormat(tt.data)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("imageFormat(%q) = %q, %v; want %q, %v", tt.data, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	script := setPhotoScript(`Jane "JD" Doe`, "/tmp/face.png", "png")
+	for _, want := range []string{`POSIX file "/tmp/face.png") as «class PNGf»`, `name is "Jane \"JD\" Doe"`, "save"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("script missing %q", want)
+		}
+	}
+}
+
+func TestSummaryFromPartsScrubsNull(t *testing.T) {
+	fields := []string{"Jane Doe", "jane@example.com", "555-1234", "Acme"}
+	for i := range fields {
+		parts := append([]string(nil), fields...)
+		parts[i] = " null "
+		got := summaryFromParts(parts)
+		values := []string{got.Name, got.Email, got.Phone, got.Company}
+		for j, v := range values {
+			want := fields[j]
+			if j == i {
+				want = ""
+			}
+			if v != want {
+				t.Errorf("null at %d: field %d = %q, want %q", i, j, v, want)
+			}
+		}
+	}
+
+	if got := scrubNull("nullable"); got != "nullable" {
+		t.Errorf("scrubNull should only match the literal null, got %q", got)
+	}
+}
+
+func TestParseGroupMembers(t *testing.T) {
+	got, err := parseGroupMembers("A1:ABPerson|||Jane Doe:::B2:ABPerson||| John Roe ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].ID != "A1:ABPerson" || got[0].Name != "Jane Doe" || got[1].Name != "John Roe" {
+		t.Errorf("members = %+v", got)
+	}
+
+	if got, err := parseGroupMembers(""); err != nil || len(got) != 0 {
+		t.Errorf("empty group = %+v, %v", got, err)
+	}
+
+	_, err = parseGroupMembers(`ERROR: Contacts got an error: Can't get group "Buyers".`)
+	if !errors.Is(err, errGroupNotFound) {
+		t.Errorf("err = %v, want errGroupNotFound", err)
+	}
+	if _, err := parseGroupMembers("ERROR: something else"); err == nil || errors.Is(err, errGroupNotFound) {
+		t.Errorf("err = %v, want a generic error", err)
+	}
+}
+
+func TestUpcomingBirthdays(t *testing.T) {
+	records := []birthdayRecord{
+		{Name: "Later", Month: 3, Day: 1, Year: 1980},
+		{Name: "Today", Month: 2, Day: 20},
+		{Name: "Leap", Month: 2, Day: 29, Year: 2000},
+		{Name: "Passed", Month: 2, Day: 10, Year: 1990},
+		{Name: "Wrap", Month: 1, Day: 5},
+	}
+	now := time.Date(2023, 2, 20, 15, 30, 0, 0, time.Local)
+
+	got := upcomingBirthdays(records, now, 30)
+	want := []UpcomingBirthday{
+		{Name: "Today", Birthday: "--02-20", Next: "2023-02-20", InDays: 0},
+		{Name: "Leap", Birthday: "2000-02-29", Next: "2023-02-28", InDays: 8, Turning: 23},
+		{Name: "Later", Birthday: "1980-03-01", Next: "2023-03-01", InDays: 9, Turning: 43},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("birthday %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	all := upcomingBirthdays(records, now, 366)
+	if last := all[len(all)-1]; last.Name != "Passed" || last.Next != "2024-02-10" || last.InDays != 355 {
+		t.Errorf("last = %+v, want Passed on 2024-02-10", last)
+	}
+}
+
+func TestReportExportFailures(t *testing.T) {
+	var b strings.Builder
+	if err := reportExportFailures(&b, nil); err != nil || b.Len() != 0 {
+		t.Errorf("no failures: err = %v, wrote %q", err, b.String())
+	}
+
+	err := reportExportFailures(&b, []ExportFailure{{ID: "A1", Name: "Jane", Message: "timed out"}})
+	if err == nil || !output.IsPrinted(err) {
+		t.Errorf("err = %v, want a printed error", err)
+	}
+	if !strings.Contains(b.String(), `"failed":1`) || !strings.Contains(b.String(), `"id":"A1"`) {
+		t.Errorf("stderr report = %q", b.String())
+	}
+}
+
+func TestTopByRelevanceLimitsAfterRanking(t *testing.T) {
+	contacts := []ContactSummary{
+		{Name: "Annabel Lee"},
+		{Name: "Joanna Ann"},
+		{Company: "Ann Arbor Realty"},
+		{Name: "Ann"},
+	}
+	got := topByRelevance(contacts, "ann", 2)
+	if len(got) != 2 || got[0].Name != "Ann" {
+		t.Errorf("top 2 = %+v, want the exact match first", got)
+	}
+}
+
+func TestGetSerialization(t *testing.T) {
+	tests := []struct {
+		format, output string
+		want           string
+		wantErr        bool
+	}{
+		{"json", "json", "json", false},
+		{"json", "table", "json", false},
+		{"json", "vcard", "vcard", false},
+		{"VCard", "json", "vcard", false},
+		{"vcard", "vcard", "vcard", false},
+		{"vcard", "table", "", true},
+		{"xml", "json", "", true},
+	}
+	for _, tt := range tests {
+		got, err := getSerialization(tt.format, tt.output)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("getSerialization(%q, %q) = %q, %v; want %q, wantErr %v",
+				tt.format, tt.output, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -23,14 +28,28 @@ var (
 
 // TimeInfo is LLM-friendly timezone information
 type TimeInfo struct {
-	Timezone     string `json:"timezone"`
-	DateTime     string `json:"datetime"`
-	UTCOffset    string `json:"utc_offset"`
-	DayOfWeek    int    `json:"day_of_week"`
-	WeekNumber   int    `json:"week_number"`
-	DST          bool   `json:"dst"`
-	Abbreviation string `json:"abbreviation"`
-	UnixTime     int64  `json:"unixtime"`
+	Timezone       string           `json:"timezone"`
+	DateTime       string           `json:"datetime"`
+	UTCOffset      string           `json:"utc_offset"`
+	DayOfWeek      int              `json:"day_of_week"` // Sunday=0..Saturday=6, or Monday=1..Sunday=7 when WeekStart is monday
+	WeekStart      string           `json:"week_start"`
+	WeekNumber     int              `json:"week_number"`
+	DST            bool             `json:"dst"`
+	Abbreviation   string           `json:"abbreviation"`
+	UnixTime       int64            `json:"unixtime"`
+	Display        string           `json:"display,omitempty"`
+	Local          *LocalComparison `json:"local,omitempty"`
+	Source         string           `json:"source,omitempty"`          // ip: "ip_lookup" or "local_fallback"
+	FallbackReason string           `json:"fallback_reason,omitempty"` // why the IP lookup was skipped
+}
+
+// LocalComparison is the caller's local time alongside a requested zone
+type LocalComparison struct {
+	Timezone        string  `json:"timezone"`
+	DateTime        string  `json:"datetime"`
+	UTCOffset       string  `json:"utc_offset"`
+	DifferenceHours float64 `json:"difference_hours"`
+	Description     string  `json:"description"`
 }
 
 // NewCmd returns the timezone command
@@ -44,38 +63,323 @@ func NewCmd() *cobra.Command {
 	cmd.AddCommand(newGetCmd())
 	cmd.AddCommand(newIPCmd())
 	cmd.AddCommand(newListCmd())
+	cmd.AddCommand(newParseCmd())
+	cmd.AddCommand(newCountryCmd())
+	cmd.AddCommand(newConflictCmd())
+	cmd.AddCommand(newOverlapCmd())
+	cmd.AddCommand(newNowCmd())
+	cmd.AddCommand(newConvertCmd())
 
 	return cmd
 }
 
 func newGetCmd() *cobra.Command {
+	var format string
+	var compareLocal bool
+	var locale string
+	var weekStart string
+
 	cmd := &cobra.Command{
 		Use:   "get [timezone]",
 		Short: "Get time for a timezone (e.g., America/New_York)",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			tz := args[0]
-			return getTimezoneLocal(tz)
+			return getTimezoneLocal(tz, format, locale, weekStart, compareLocal)
 		},
 	}
 
+	cmd.Flags().StringVarP(&format, "format", "f", "", "Add a display field: rfc3339, 12h, 24h, kitchen, or a Go layout")
+	cmd.Flags().StringVar(&locale, "locale", "", "Render display day/month names in es, fr, de, or ja")
+	cmd.Flags().BoolVar(&compareLocal, "compare-local", false, "Include local time and the offset difference from the requested zone")
+	cmd.Flags().StringVar(&weekStart, "week-start", "sunday", "Numbering of day_of_week: sunday (Sunday=0) or monday (Monday=1..Sunday=7)")
+
 	return cmd
 }
 
 func newIPCmd() *cobra.Command {
+	var self bool
+
 	cmd := &cobra.Command{
 		Use:   "ip [ip-address]",
 		Short: "Get timezone by IP address",
-		Args:  cobra.ExactArgs(1),
+		Long: `Look up the timezone of an IP address. With --self, or no address, the
+caller's public IP is discovered first. If that fails (e.g. offline), the
+system's local timezone is reported instead, with source "local_fallback"
+and a fallback_reason; successful lookups have source "ip_lookup".`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			ip := args[0]
+			if len(args) == 1 && self {
+				return output.PrintError("invalid_input", "Pass an IP address or --self, not both", nil)
+			}
+			if len(args) == 1 {
+				return fetchTimezoneByIP(args[0])
+			}
+
+			ip, err := publicIP()
+			if err != nil {
+				result, lerr := localTimeInfo(localZoneName(), "", "", "", false)
+				if lerr != nil {
+					return lerr
+				}
+				result.Source = "local_fallback"
+				result.FallbackReason = fmt.Sprintf("public IP lookup failed: %v", err)
+				return output.Print(result)
+			}
 			return fetchTimezoneByIP(ip)
 		},
 	}
 
+	cmd.Flags().BoolVar(&self, "self", false, "Look up the caller's own public IP")
+
 	return cmd
 }
 
+// publicIPURL returns the caller's public IP address as plain text
+var publicIPURL = "https://api.ipify.org"
+
+// publicIP discovers the caller's public IP address
+func publicIP() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", publicIPURL, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "Pocket-CLI/1.0")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return "", fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64))
+	if err != nil {
+		return "", err
+	}
+	ip := strings.TrimSpace(string(body))
+	if net.ParseIP(ip) == nil {
+		return "", fmt.Errorf("unexpected response: %q", ip)
+	}
+	return ip, nil
+}
+
+// localZoneName returns the IANA name of the system timezone when it can be
+// determined from $TZ or the /etc/localtime link, or "Local" otherwise
+func localZoneName() string {
+	if tz := os.Getenv("TZ"); tz != "" {
+		if _, err := time.LoadLocation(tz); err == nil {
+			return tz
+		}
+	}
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if i := strings.Index(target, "zoneinfo/"); i >= 0 {
+			name := target[i+len("zoneinfo/"):]
+			if _, err := time.LoadLocation(name); err == nil {
+				return name
+			}
+		}
+	}
+	return "Local"
+}
+
+func newCountryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "country [iso-code]",
+		Short: "Get the IANA timezone(s) for a country code (offline)",
+		Long: `Map an ISO 3166-1 alpha-2 country code to its IANA timezone(s) using an
+embedded table. Useful as a network-free fallback when IP lookup is unavailable.
+Countries spanning several zones return every candidate, primary first.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return lookupCountryZones(args[0])
+		},
+	}
+
+	return cmd
+}
+
+func newConflictCmd() *cobra.Command {
+	var slot string
+	var zone string
+	var against []string
+
+	cmd := &cobra.Command{
+		Use:   "conflict",
+		Short: "Check a proposed meeting time against participants' working hours",
+		Long: `Report, for a proposed meeting instant, the local time in each participant's
+timezone and whether it falls within their working hours.
+
+Participants are given as Zone:HH:MM-HH:MM, e.g.
+  --against Europe/London:09:00-17:00,Asia/Tokyo:09:00-18:00`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return checkConflicts(slot, zone, against)
+		},
+	}
+
+	cmd.Flags().StringVar(&slot, "slot", "", "Proposed meeting time, e.g. \"2024-06-01 14:00\" (required)")
+	cmd.Flags().StringVar(&zone, "zone", "UTC", "Timezone the slot is expressed in")
+	cmd.Flags().StringSliceVar(&against, "against", nil, "Participant working hours as Zone:HH:MM-HH:MM (required)")
+	_ = cmd.MarkFlagRequired("slot")
+	_ = cmd.MarkFlagRequired("against")
+
+	return cmd
+}
+
+func newOverlapCmd() *cobra.Command {
+	var participants []string
+	var date string
+	var zone string
+	var slots bool
+	var granularity time.Duration
+	var duration time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "overlap",
+		Short: "Find the window where all participants' working hours overlap",
+		Long: `Compute the periods on a given day when every participant is within their
+working hours. With --slots, emit candidate meeting start times every
+--granularity within those periods, keeping only starts where a meeting of
+--duration ends inside everyone's hours. Each slot is rendered in every
+participant's local time.
+
+Participants are given as Zone:HH:MM-HH:MM, e.g.
+  --participants America/New_York:09:00-17:00,Europe/London:09:00-17:00`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if granularity <= 0 || granularity%time.Minute != 0 {
+				return output.PrintError("invalid_input", "--granularity must be a positive whole number of minutes", nil)
+			}
+			if duration <= 0 || duration%time.Minute != 0 {
+				return output.PrintError("invalid_input", "--duration must be a positive whole number of minutes", nil)
+			}
+			return findOverlap(participants, date, zone, slots, granularity, duration)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&participants, "participants", nil, "Participant working hours as Zone:HH:MM-HH:MM (required)")
+	cmd.Flags().StringVar(&date, "date", "", "Day to plan, YYYY-MM-DD (default today in --zone)")
+	cmd.Flags().StringVar(&zone, "zone", "UTC", "Timezone the day is expressed in")
+	cmd.Flags().BoolVar(&slots, "slots", false, "Emit discrete candidate meeting start times")
+	cmd.Flags().DurationVar(&granularity, "granularity", 30*time.Minute, "Spacing between candidate slot starts")
+	cmd.Flags().DurationVar(&duration, "duration", 30*time.Minute, "Meeting length each slot must fit")
+	_ = cmd.MarkFlagRequired("participants")
+
+	return cmd
+}
+
+func newNowCmd() *cobra.Command {
+	var table bool
+	var sortBy string
+
+	cmd := &cobra.Command{
+		Use:   "now [zones...]",
+		Short: "World clock: current time in several timezones",
+		Long: `Show the current time in each given IANA timezone (default: local and UTC).
+Use --table for an aligned Zone | Local Time | Offset | Day table.
+
+Zones are listed in argument order unless --sort is given: offset orders them
+east to west (largest UTC offset first), time by local wall-clock time
+(earliest first), and name alphabetically.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			zones := args
+			if len(zones) == 0 {
+				zones = []string{time.Local.String(), "UTC"}
+			}
+
+			clocks, err := worldClock(zones, time.Now())
+			if err != nil {
+				return output.PrintError("not_found", err.Error(), nil)
+			}
+			if err := sortClocks(clocks, sortBy); err != nil {
+				return output.PrintError("invalid_input", err.Error(),
+					map[string]any{"supported": []string{"offset", "name", "time"}})
+			}
+
+			if table {
+				return writeClockTable(os.Stdout, clocks)
+			}
+
+			return output.Print(map[string]any{
+				"count": len(clocks),
+				"zones": clocks,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&table, "table", false, "Render an aligned human-readable table")
+	cmd.Flags().StringVar(&sortBy, "sort", "", "Order zones by offset, name or time (default: argument order)")
+
+	return cmd
+}
+
+// ZoneClock is the current time in a single timezone
+type ZoneClock struct {
+	Zone      string `json:"zone"`
+	LocalTime string `json:"local_time"`
+	UTCOffset string `json:"utc_offset"`
+	Day       string `json:"day"`
+	DateTime  string `json:"datetime"`
+}
+
+// worldClock renders instant in each zone, failing on the first unknown zone
+func worldClock(zones []string, instant time.Time) ([]ZoneClock, error) {
+	clocks := make([]ZoneClock, 0, len(zones))
+	for _, z := range zones {
+		loc, err := time.LoadLocation(z)
+		if err != nil {
+			return nil, fmt.Errorf("timezone not found: %s", z)
+		}
+		t := instant.In(loc)
+		clocks = append(clocks, ZoneClock{
+			Zone:      z,
+			LocalTime: t.Format("15:04"),
+			UTCOffset: t.Format("-07:00"),
+			Day:       t.Format("Mon Jan 2"),
+			DateTime:  t.Format(time.RFC3339),
+		})
+	}
+	return clocks, nil
+}
+
+// sortClocks orders clocks in place by "offset" (east to west), "name" or
+// "time" (earliest local time first); "" keeps the given order
+func sortClocks(clocks []ZoneClock, by string) error {
+	offset := func(c ZoneClock) int {
+		t, _ := time.Parse(time.RFC3339, c.DateTime)
+		_, secs := t.Zone()
+		return secs
+	}
+
+	switch by {
+	case "":
+	case "offset":
+		sort.SliceStable(clocks, func(i, j int) bool { return offset(clocks[i]) > offset(clocks[j]) })
+	case "time":
+		sort.SliceStable(clocks, func(i, j int) bool { return offset(clocks[i]) < offset(clocks[j]) })
+	case "name":
+		sort.SliceStable(clocks, func(i, j int) bool { return clocks[i].Zone < clocks[j].Zone })
+	default:
+		return fmt.Errorf("unsupported sort: %s", by)
+	}
+	return nil
+}
+
+// writeClockTable writes clocks as an aligned Zone | Local Time | Offset | Day table
+func writeClockTable(w io.Writer, clocks []ZoneClock) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ZONE\tLOCAL TIME\tOFFSET\tDAY")
+	for _, c := range clocks {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", c.Zone, c.LocalTime, c.UTCOffset, c.Day)
+	}
+	return tw.Flush()
+}
+
 func newListCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -88,12 +392,185 @@ func newListCmd() *cobra.Command {
 	return cmd
 }
 
+func newParseCmd() *cobra.Command {
+	var layout string
+	var tz string
+
+	cmd := &cobra.Command{
+		Use:   "parse [datetime]",
+		Short: "Parse an arbitrary datetime string into RFC3339 and Unix time",
+		Long: `Parse a datetime string by trying a set of common layouts (RFC3339, RFC1123,
+"2006-01-02 15:04", US "01/02/2006", etc.). Use --layout to supply a Go
+reference layout explicitly. Inputs without a zone are interpreted in --tz.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return parseDateTimeCmd(strings.Join(args, " "), layout, tz)
+		},
+	}
+
+	cmd.Flags().StringVarP(&layout, "layout", "l", "", "Go reference layout to use instead of auto-detection")
+	cmd.Flags().StringVar(&tz, "tz", "UTC", "Timezone for inputs without zone information")
+
+	return cmd
+}
+
+// displayLayouts maps named --format values to Go time layouts
+var displayLayouts = map[string]string{
+	"rfc3339": time.RFC3339,
+	"12h":     "3:04 PM",
+	"24h":     "15:04",
+	"kitchen": time.Kitchen,
+}
+
+// formatDisplay formats t using a named format or, failing that, treats
+// format as a custom Go layout string.
+func formatDisplay(t time.Time, format string) string {
+	return t.Format(displayLayout(format))
+}
+
+// displayLayout resolves a named format to its layout; anything else is
+// already a layout
+func displayLayout(format string) string {
+	if layout, ok := displayLayouts[strings.ToLower(format)]; ok {
+		return layout
+	}
+	return format
+}
+
+// defaultLocaleFormat is the display layout used when --locale is given without --format
+const defaultLocaleFormat = "Monday, 2 January 2006 15:04"
+
+// localeNames holds translated weekday and month names, indexed like
+// time.Weekday (Sunday first) and time.Month minus one
+type localeNames struct {
+	Days        [7]string
+	ShortDays   [7]string
+	Months      [12]string
+	ShortMonths [12]string
+}
+
+// localeTable covers the handful of locales the display field supports;
+// Go's time formatting is English-only.
+var localeTable = map[string]localeNames{
+	"es": {
+		Days:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		ShortDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		Months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		ShortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+	},
+	"fr": {
+		Days:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		ShortDays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		Months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		ShortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+	},
+	"de": {
+		Days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		ShortDays:   [7]string{"So", "Mo", "Di", "Mi", "Do", "Fr", "Sa"},
+		Months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		ShortMonths: [12]string{"Jan", "Feb", "Mär", "Apr", "Mai", "Jun", "Jul", "Aug", "Sep", "Okt", "Nov", "Dez"},
+	},
+	"ja": {
+		Days:        [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		ShortDays:   [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		Months:      [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		ShortMonths: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+	},
+}
+
+// format formats t with layout, writing the locale's weekday and month
+// names wherever the layout has a name element. Names come from the layout's
+// elements rather than the English output, so the full and short forms of
+// May stay distinct and literal text in a custom layout is left alone.
+func (n localeNames) format(t time.Time, layout string) string {
+	day, month := int(t.Weekday()), int(t.Month())-1
+	var b strings.Builder
+	start := 0
+	for i := 0; i < len(layout); i++ {
+		rest := layout[i:]
+		var name string
+		var width int
+		switch {
+		case strings.HasPrefix(rest, "January"):
+			name, width = n.Months[month], len("January")
+		case strings.HasPrefix(rest, "Monday"):
+			name, width = n.Days[day], len("Monday")
+		case strings.HasPrefix(rest, "Jan") && !startsLower(rest[3:]):
+			name, width = n.ShortMonths[month], len("Jan")
+		case strings.HasPrefix(rest, "Mon") && !startsLower(rest[3:]):
+			name, width = n.ShortDays[day], len("Mon")
+		case strings.HasPrefix(rest, "MST"):
+			// Its M doesn't start a name, nor does PM's
+			i += len("MST") - 1
+			continue
+		case strings.HasPrefix(rest, "PM"):
+			i += len("PM") - 1
+			continue
+		default:
+			continue
+		}
+		b.WriteString(t.Format(layout[start:i]))
+		b.WriteString(name)
+		start = i + width
+		i = start - 1
+	}
+	b.WriteString(t.Format(layout[start:]))
+	return b.String()
+}
+
+// startsLower reports whether s begins with a lowercase letter, which Go's
+// layout parser takes to mean "Jan" or "Mon" is ordinary text, as in "Monet"
+func startsLower(s string) bool {
+	return s != "" && 'a' <= s[0] && s[0] <= 'z'
+}
+
+// dayOfWeek numbers a weekday from Sunday=0, or from Monday=1 to Sunday=7
+// when weekStart is "monday"
+func dayOfWeek(d time.Weekday, weekStart string) int {
+	if weekStart == "monday" && d == time.Sunday {
+		return 7
+	}
+	return int(d)
+}
+
 // getTimezoneLocal uses Go's built-in time package to get timezone info
 // without requiring any external API.
-func getTimezoneLocal(tz string) error {
+func getTimezoneLocal(tz, format, locale, weekStart string, compareLocal bool) error {
+	result, err := localTimeInfo(tz, format, locale, weekStart, compareLocal)
+	if err != nil {
+		return err
+	}
+	return output.Print(result)
+}
+
+// localTimeInfo builds the TimeInfo for tz from the system clock. Errors are
+// already printed.
+func localTimeInfo(tz, format, locale, weekStart string, compareLocal bool) (TimeInfo, error) {
 	loc, err := time.LoadLocation(tz)
 	if err != nil {
-		return output.PrintError("not_found", fmt.Sprintf("Timezone not found: %s", tz), nil)
+		return TimeInfo{}, output.PrintError("not_found", fmt.Sprintf("Timezone not found: %s", tz), nil)
+	}
+
+	weekStart = strings.ToLower(weekStart)
+	if weekStart == "" {
+		weekStart = "sunday"
+	}
+	if weekStart != "sunday" && weekStart != "monday" {
+		return TimeInfo{}, output.PrintError("invalid_input", fmt.Sprintf("Unsupported week start: %s", weekStart),
+			map[string]any{"supported": []string{"sunday", "monday"}})
+	}
+
+	var names *localeNames
+	if locale != "" {
+		n, ok := localeTable[strings.ToLower(locale)]
+		if !ok {
+			return TimeInfo{}, output.PrintError("invalid_input", fmt.Sprintf("Unsupported locale: %s", locale),
+				map[string]any{"supported": []string{"de", "es", "fr", "ja"}})
+		}
+		names = &n
+		if format == "" {
+			format = defaultLocaleFormat
+		}
 	}
 
 	now := time.Now().In(loc)
@@ -118,13 +595,517 @@ func getTimezoneLocal(tz string) error {
 		Timezone:     tz,
 		DateTime:     now.Format(time.RFC3339),
 		UTCOffset:    utcOffset,
-		DayOfWeek:    int(now.Weekday()),
+		DayOfWeek:    dayOfWeek(now.Weekday(), weekStart),
+		WeekStart:    weekStart,
 		WeekNumber:   isoWeek,
 		DST:          now.IsDST(),
 		Abbreviation: zone,
 		UnixTime:     now.Unix(),
 	}
 
+	if format != "" {
+		if names != nil {
+			result.Display = names.format(now, displayLayout(format))
+		} else {
+			result.Display = formatDisplay(now, format)
+		}
+	}
+
+	if compareLocal {
+		cmp := compareToLocal(now, now.In(time.Local))
+		result.Local = &cmp
+	}
+
+	return result, nil
+}
+
+// compareToLocal describes local relative to the requested zone time remote.
+// Both must represent the same instant.
+func compareToLocal(remote, local time.Time) LocalComparison {
+	_, remoteOffset := remote.Zone()
+	_, localOffset := local.Zone()
+	diff := remoteOffset - localOffset
+
+	var desc string
+	switch {
+	case diff == 0:
+		desc = "same time as local"
+	case diff > 0:
+		desc = fmt.Sprintf("%s ahead of local", formatOffsetDiff(diff))
+	default:
+		desc = fmt.Sprintf("%s behind local", formatOffsetDiff(-diff))
+	}
+
+	return LocalComparison{
+		Timezone:        local.Location().String(),
+		DateTime:        local.Format(time.RFC3339),
+		UTCOffset:       local.Format("-07:00"),
+		DifferenceHours: float64(diff) / 3600,
+		Description:     desc,
+	}
+}
+
+// formatOffsetDiff renders a positive offset in seconds as e.g. "5h" or "5h30m"
+func formatOffsetDiff(seconds int) string {
+	h := seconds / 3600
+	m := (seconds % 3600) / 60
+	if m == 0 {
+		return fmt.Sprintf("%dh", h)
+	}
+	return fmt.Sprintf("%dh%02dm", h, m)
+}
+
+// ParsedTime is the result of normalizing a datetime string
+type ParsedTime struct {
+	Input    string `json:"input"`
+	DateTime string `json:"datetime"`
+	UnixTime int64  `json:"unixtime"`
+	Layout   string `json:"layout"`
+}
+
+// parseLayouts are tried in order when no explicit layout is given.
+// More specific layouts come first so that zone and seconds are not dropped.
+var parseLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+	time.UnixDate,
+	time.RubyDate,
+	time.ANSIC,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05 MST",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"01/02/2006 3:04:05 PM",
+	"01/02/2006 3:04 PM",
+	"01/02/2006 15:04:05",
+	"01/02/2006 15:04",
+	"01/02/2006",
+	"1/2/2006",
+	"Jan 2 2006 3:04pm MST",
+	"Jan 2 2006 3:04pm",
+	"Jan 2 2006 3:04 PM",
+	"Jan 2 2006 15:04",
+	"Jan 2, 2006 3:04 PM",
+	"Jan 2, 2006 15:04",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006",
+	"2 Jan 2006 15:04",
+	"2 Jan 2006",
+	"2 January 2006",
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon Jan 2 2006 15:04:05",
+}
+
+// parseDateTime tries each layout in turn and returns the parsed time along
+// with the layout that matched.
+func parseDateTime(input string, layouts []string, loc *time.Location) (time.Time, string, error) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, input, loc); err == nil {
+			return t, layout, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("no layout matched %q", input)
+}
+
+func parseDateTimeCmd(input, layout, tz string) error {
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return output.PrintError("not_found", fmt.Sprintf("Timezone not found: %s", tz), nil)
+	}
+
+	layouts := parseLayouts
+	if layout != "" {
+		layouts = []string{layout}
+	}
+
+	t, matched, err := parseDateTime(strings.TrimSpace(input), layouts, loc)
+	if err != nil {
+		return output.PrintError("parse_failed", err.Error(), map[string]any{
+			"input":             input,
+			"attempted_layouts": layouts,
+		})
+	}
+
+	return output.Print(ParsedTime{
+		Input:    input,
+		DateTime: t.Format(time.RFC3339),
+		UnixTime: t.Unix(),
+		Layout:   matched,
+	})
+}
+
+// ZoneTime is an instant expressed in one timezone
+type ZoneTime struct {
+	Timezone     string `json:"timezone"`
+	DateTime     string `json:"datetime"`
+	UTCOffset    string `json:"utc_offset"`
+	Abbreviation string `json:"abbreviation"`
+}
+
+// Conversion is a datetime converted from one timezone to another
+type Conversion struct {
+	Input    string   `json:"input"`
+	From     ZoneTime `json:"from"`
+	To       ZoneTime `json:"to"`
+	UnixTime *int64   `json:"unixtime,omitempty"`
+}
+
+func newConvertCmd() *cobra.Command {
+	var from, to, layout string
+	var epochOut bool
+	var fromEpoch, fromEpochMs int64
+
+	cmd := &cobra.Command{
+		Use:   "convert [datetime]",
+		Short: "Convert a datetime from one timezone to another",
+		Long: `Convert a datetime (any layout accepted by parse, or "now") from --from to
+--to. Inputs that carry their own zone or offset keep it; others are read in
+--from. Use --epoch-out to include the Unix timestamp of the instant.
+
+Instead of a datetime, --from-epoch (seconds) or --from-epoch-ms
+(milliseconds) takes a Unix timestamp, e.g. one copied from a log.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			epochSet := cmd.Flags().Changed("from-epoch") || cmd.Flags().Changed("from-epoch-ms")
+			if epochSet && len(args) > 0 {
+				return output.PrintError("invalid_input", "Give either a datetime or --from-epoch/--from-epoch-ms, not both", nil)
+			}
+			if !epochSet && len(args) == 0 {
+				return output.PrintError("invalid_input", "A datetime, --from-epoch or --from-epoch-ms is required", nil)
+			}
+			input := strings.TrimSpace(strings.Join(args, " "))
+
+			fromLoc, err := time.LoadLocation(from)
+			if err != nil {
+				return output.PrintError("not_found", fmt.Sprintf("Timezone not found: %s", from), nil)
+			}
+			toLoc, err := time.LoadLocation(to)
+			if err != nil {
+				return output.PrintError("not_found", fmt.Sprintf("Timezone not found: %s", to), nil)
+			}
+
+			var t time.Time
+			switch {
+			case cmd.Flags().Changed("from-epoch"):
+				input = strconv.FormatInt(fromEpoch, 10)
+				t = time.Unix(fromEpoch, 0)
+			case cmd.Flags().Changed("from-epoch-ms"):
+				input = strconv.FormatInt(fromEpochMs, 10)
+				t = time.UnixMilli(fromEpochMs)
+			case strings.EqualFold(input, "now"):
+				t = time.Now()
+			default:
+				layouts := parseLayouts
+				if layout != "" {
+					layouts = []string{layout}
+				}
+				if t, _, err = parseDateTime(input, layouts, fromLoc); err != nil {
+					return output.PrintError("parse_failed", err.Error(), map[string]any{
+						"input":             input,
+						"attempted_layouts": layouts,
+					})
+				}
+			}
+
+			return output.Print(convertTime(input, t, fromLoc, toLoc, epochOut))
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "UTC", "Source timezone (IANA name)")
+	cmd.Flags().StringVar(&to, "to", "Local", "Target timezone (IANA name)")
+	cmd.Flags().StringVarP(&layout, "layout", "l", "", "Go reference layout to use instead of auto-detection")
+	cmd.Flags().BoolVar(&epochOut, "epoch-out", false, "Include the Unix timestamp of the converted instant")
+	cmd.Flags().Int64Var(&fromEpoch, "from-epoch", 0, "Convert this Unix time in seconds instead of a datetime")
+	cmd.Flags().Int64Var(&fromEpochMs, "from-epoch-ms", 0, "Convert this Unix time in milliseconds instead of a datetime")
+	cmd.MarkFlagsMutuallyExclusive("from-epoch", "from-epoch-ms")
+	cmd.MarkFlagsMutuallyExclusive("from-epoch", "layout")
+	cmd.MarkFlagsMutuallyExclusive("from-epoch-ms", "layout")
+
+	return cmd
+}
+
+// convertTime expresses t in both zones, adding the Unix time when epochOut is set
+func convertTime(input string, t time.Time, from, to *time.Location, epochOut bool) Conversion {
+	c := Conversion{
+		Input: input,
+		From:  zoneTime(t.In(from)),
+		To:    zoneTime(t.In(to)),
+	}
+	if epochOut {
+		unix := t.Unix()
+		c.UnixTime = &unix
+	}
+	return c
+}
+
+// zoneTime describes t in its own location
+func zoneTime(t time.Time) ZoneTime {
+	abbr, _ := t.Zone()
+	return ZoneTime{
+		Timezone:     t.Location().String(),
+		DateTime:     t.Format(time.RFC3339),
+		UTCOffset:    t.Format("-07:00"),
+		Abbreviation: abbr,
+	}
+}
+
+// workingHours is a participant's daily availability in their own timezone
+type workingHours struct {
+	Zone  string
+	Loc   *time.Location
+	Start int // minutes since midnight
+	End   int // minutes since midnight
+}
+
+// ParticipantCheck is the conflict status of one participant
+type ParticipantCheck struct {
+	Zone         string `json:"zone"`
+	LocalTime    string `json:"local_time"`
+	WorkingHours string `json:"working_hours"`
+	WithinHours  bool   `json:"within_hours"`
+}
+
+// parseClock parses "HH:MM" into minutes since midnight
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// parseWorkingHours parses "Zone:HH:MM-HH:MM"
+func parseWorkingHours(spec string) (workingHours, error) {
+	zone, hours, ok := strings.Cut(spec, ":")
+	if !ok {
+		return workingHours{}, fmt.Errorf("invalid participant %q, expected Zone:HH:MM-HH:MM", spec)
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return workingHours{}, fmt.Errorf("timezone not found: %s", zone)
+	}
+	startStr, endStr, ok := strings.Cut(hours, "-")
+	if !ok {
+		return workingHours{}, fmt.Errorf("invalid hours %q, expected HH:MM-HH:MM", hours)
+	}
+	start, err := parseClock(startStr)
+	if err != nil {
+		return workingHours{}, err
+	}
+	end, err := parseClock(endStr)
+	if err != nil {
+		return workingHours{}, err
+	}
+	return workingHours{Zone: zone, Loc: loc, Start: start, End: end}, nil
+}
+
+// contains reports whether t falls within the working hours in the
+// participant's zone. Ranges whose end precedes the start wrap past midnight.
+func (w workingHours) contains(t time.Time) bool {
+	local := t.In(w.Loc)
+	m := local.Hour()*60 + local.Minute()
+	if w.End > w.Start {
+		return m >= w.Start && m < w.End
+	}
+	return m >= w.Start || m < w.End
+}
+
+func checkConflicts(slot, zone string, against []string) error {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return output.PrintError("not_found", fmt.Sprintf("Timezone not found: %s", zone), nil)
+	}
+
+	t, _, err := parseDateTime(strings.TrimSpace(slot), parseLayouts, loc)
+	if err != nil {
+		return output.PrintError("parse_failed", err.Error(), nil)
+	}
+
+	checks := make([]ParticipantCheck, 0, len(against))
+	conflicts := []string{}
+	for _, spec := range against {
+		wh, err := parseWorkingHours(spec)
+		if err != nil {
+			return output.PrintError("invalid_input", err.Error(), nil)
+		}
+		within := wh.contains(t)
+		if !within {
+			conflicts = append(conflicts, wh.Zone)
+		}
+		_, hours, _ := strings.Cut(spec, ":")
+		checks = append(checks, ParticipantCheck{
+			Zone:         wh.Zone,
+			LocalTime:    t.In(wh.Loc).Format(time.RFC3339),
+			WorkingHours: hours,
+			WithinHours:  within,
+		})
+	}
+
+	return output.Print(map[string]any{
+		"slot":         t.Format(time.RFC3339),
+		"utc":          t.UTC().Format(time.RFC3339),
+		"participants": checks,
+		"conflicts":    conflicts,
+		"has_conflict": len(conflicts) > 0,
+	})
+}
+
+// OverlapWindow is a period when every participant is within working hours
+type OverlapWindow struct {
+	Start   string            `json:"start"`
+	End     string            `json:"end"`
+	Minutes int               `json:"minutes"`
+	Local   []ParticipantTime `json:"local"`
+}
+
+// MeetingSlot is a candidate meeting start that fits everyone's hours
+type MeetingSlot struct {
+	Start string            `json:"start"`
+	End   string            `json:"end"`
+	Local []ParticipantTime `json:"local"`
+}
+
+// ParticipantTime is a slot boundary rendered in one participant's zone
+type ParticipantTime struct {
+	Zone  string `json:"zone"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// timeRange is a half-open [Start, End) interval
+type timeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// withinAll reports whether t falls within every participant's hours
+func withinAll(t time.Time, participants []workingHours) bool {
+	for _, p := range participants {
+		if !p.contains(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// overlapRanges scans [from, to) minute by minute and returns the maximal
+// ranges in which every participant is within working hours.
+func overlapRanges(from, to time.Time, participants []workingHours) []timeRange {
+	var ranges []timeRange
+	var open *timeRange
+	for t := from; t.Before(to); t = t.Add(time.Minute) {
+		if withinAll(t, participants) {
+			if open == nil {
+				open = &timeRange{Start: t}
+			}
+			continue
+		}
+		if open != nil {
+			open.End = t
+			ranges = append(ranges, *open)
+			open = nil
+		}
+	}
+	if open != nil {
+		open.End = to
+		ranges = append(ranges, *open)
+	}
+	return ranges
+}
+
+// meetingSlots returns start times, stepping by granularity from the start
+// of each range, where a meeting of the given duration stays inside the
+// range. Ranges are maximal, so fitting the range means fitting everyone.
+func meetingSlots(ranges []timeRange, granularity, duration time.Duration) []timeRange {
+	var slots []timeRange
+	for _, r := range ranges {
+		for t := r.Start; !t.Add(duration).After(r.End); t = t.Add(granularity) {
+			slots = append(slots, timeRange{Start: t, End: t.Add(duration)})
+		}
+	}
+	return slots
+}
+
+// localTimes renders a range in each participant's zone
+func localTimes(r timeRange, participants []workingHours) []ParticipantTime {
+	local := make([]ParticipantTime, 0, len(participants))
+	for _, p := range participants {
+		local = append(local, ParticipantTime{
+			Zone:  p.Zone,
+			Start: r.Start.In(p.Loc).Format(time.RFC3339),
+			End:   r.End.In(p.Loc).Format(time.RFC3339),
+		})
+	}
+	return local
+}
+
+func findOverlap(specs []string, date, zone string, slots bool, granularity, duration time.Duration) error {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return output.PrintError("not_found", fmt.Sprintf("Timezone not found: %s", zone), nil)
+	}
+
+	day := time.Now().In(loc)
+	if date != "" {
+		day, err = time.ParseInLocation("2006-01-02", strings.TrimSpace(date), loc)
+		if err != nil {
+			return output.PrintError("invalid_input", fmt.Sprintf("invalid --date %q, expected YYYY-MM-DD", date), nil)
+		}
+	}
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 0, 1)
+
+	participants := make([]workingHours, 0, len(specs))
+	for _, spec := range specs {
+		wh, err := parseWorkingHours(spec)
+		if err != nil {
+			return output.PrintError("invalid_input", err.Error(), nil)
+		}
+		participants = append(participants, wh)
+	}
+
+	ranges := overlapRanges(from, to, participants)
+	result := map[string]any{
+		"date":        from.Format("2006-01-02"),
+		"zone":        zone,
+		"has_overlap": len(ranges) > 0,
+	}
+
+	if slots {
+		candidates := meetingSlots(ranges, granularity, duration)
+		out := make([]MeetingSlot, 0, len(candidates))
+		for _, c := range candidates {
+			out = append(out, MeetingSlot{
+				Start: c.Start.Format(time.RFC3339),
+				End:   c.End.Format(time.RFC3339),
+				Local: localTimes(c, participants),
+			})
+		}
+		result["granularity_minutes"] = int(granularity / time.Minute)
+		result["duration_minutes"] = int(duration / time.Minute)
+		result["slots"] = out
+		return output.Print(result)
+	}
+
+	windows := make([]OverlapWindow, 0, len(ranges))
+	for _, r := range ranges {
+		windows = append(windows, OverlapWindow{
+			Start:   r.Start.Format(time.RFC3339),
+			End:     r.End.Format(time.RFC3339),
+			Minutes: int(r.End.Sub(r.Start) / time.Minute),
+			Local:   localTimes(r, participants),
+		})
+	}
+	result["windows"] = windows
 	return output.Print(result)
 }
 
@@ -221,15 +1202,130 @@ func fetchTimezoneByIP(ip string) error {
 		DateTime:     dateTime,
 		UTCOffset:    utcOffset,
 		DayOfWeek:    dayOfWeek,
+		WeekStart:    "sunday",
 		WeekNumber:   weekNumber,
 		DST:          data.DSTActive,
 		Abbreviation: abbrev,
 		UnixTime:     time.Now().Unix(),
+		Source:       "ip_lookup",
 	}
 
 	return output.Print(result)
 }
 
+// CountryZones is the set of timezones for a country
+type CountryZones struct {
+	Country string   `json:"country"`
+	Primary string   `json:"primary"`
+	Zones   []string `json:"zones"`
+	Multi   bool     `json:"multi_zone"`
+}
+
+// countryZones maps ISO 3166-1 alpha-2 codes to IANA zones, primary zone first.
+var countryZones = map[string][]string{
+	"AE": {"Asia/Dubai"},
+	"AF": {"Asia/Kabul"},
+	"AR": {"America/Argentina/Buenos_Aires", "America/Argentina/Cordoba", "America/Argentina/Mendoza", "America/Argentina/Ushuaia"},
+	"AT": {"Europe/Vienna"},
+	"AU": {"Australia/Sydney", "Australia/Melbourne", "Australia/Brisbane", "Australia/Adelaide", "Australia/Darwin", "Australia/Perth", "Australia/Hobart"},
+	"BD": {"Asia/Dhaka"},
+	"BE": {"Europe/Brussels"},
+	"BG": {"Europe/Sofia"},
+	"BR": {"America/Sao_Paulo", "America/Manaus", "America/Fortaleza", "America/Recife", "America/Belem", "America/Cuiaba", "America/Porto_Velho", "America/Rio_Branco", "America/Noronha"},
+	"CA": {"America/Toronto", "America/Vancouver", "America/Edmonton", "America/Winnipeg", "America/Regina", "America/Halifax", "America/St_Johns", "America/Whitehorse"},
+	"CH": {"Europe/Zurich"},
+	"CL": {"America/Santiago", "Pacific/Easter"},
+	"CN": {"Asia/Shanghai", "Asia/Urumqi"},
+	"CO": {"America/Bogota"},
+	"CZ": {"Europe/Prague"},
+	"DE": {"Europe/Berlin"},
+	"DK": {"Europe/Copenhagen"},
+	"DZ": {"Africa/Algiers"},
+	"EC": {"America/Guayaquil", "Pacific/Galapagos"},
+	"EE": {"Europe/Tallinn"},
+	"EG": {"Africa/Cairo"},
+	"ES": {"Europe/Madrid", "Atlantic/Canary"},
+	"ET": {"Africa/Addis_Ababa"},
+	"FI": {"Europe/Helsinki"},
+	"FR": {"Europe/Paris"},
+	"GB": {"Europe/London"},
+	"GH": {"Africa/Accra"},
+	"GR": {"Europe/Athens"},
+	"HK": {"Asia/Hong_Kong"},
+	"HR": {"Europe/Zagreb"},
+	"HU": {"Europe/Budapest"},
+	"ID": {"Asia/Jakarta", "Asia/Makassar", "Asia/Jayapura", "Asia/Pontianak"},
+	"IE": {"Europe/Dublin"},
+	"IL": {"Asia/Jerusalem"},
+	"IN": {"Asia/Kolkata"},
+	"IQ": {"Asia/Baghdad"},
+	"IR": {"Asia/Tehran"},
+	"IS": {"Atlantic/Reykjavik"},
+	"IT": {"Europe/Rome"},
+	"JM": {"America/Jamaica"},
+	"JO": {"Asia/Amman"},
+	"JP": {"Asia/Tokyo"},
+	"KE": {"Africa/Nairobi"},
+	"KR": {"Asia/Seoul"},
+	"KW": {"Asia/Kuwait"},
+	"KZ": {"Asia/Almaty", "Asia/Qyzylorda", "Asia/Aqtobe", "Asia/Aqtau", "Asia/Oral"},
+	"LB": {"Asia/Beirut"},
+	"LK": {"Asia/Colombo"},
+	"LT": {"Europe/Vilnius"},
+	"LU": {"Europe/Luxembourg"},
+	"LV": {"Europe/Riga"},
+	"MA": {"Africa/Casablanca"},
+	"MX": {"America/Mexico_City", "America/Cancun", "America/Monterrey", "America/Chihuahua", "America/Mazatlan", "America/Hermosillo", "America/Tijuana"},
+	"MY": {"Asia/Kuala_Lumpur", "Asia/Kuching"},
+	"NG": {"Africa/Lagos"},
+	"NL": {"Europe/Amsterdam"},
+	"NO": {"Europe/Oslo"},
+	"NP": {"Asia/Kathmandu"},
+	"NZ": {"Pacific/Auckland", "Pacific/Chatham"},
+	"PE": {"America/Lima"},
+	"PH": {"Asia/Manila"},
+	"PK": {"Asia/Karachi"},
+	"PL": {"Europe/Warsaw"},
+	"PT": {"Europe/Lisbon", "Atlantic/Madeira", "Atlantic/Azores"},
+	"QA": {"Asia/Qatar"},
+	"RO": {"Europe/Bucharest"},
+	"RS": {"Europe/Belgrade"},
+	"RU": {"Europe/Moscow", "Europe/Kaliningrad", "Europe/Samara", "Asia/Yekaterinburg", "Asia/Omsk", "Asia/Novosibirsk", "Asia/Krasnoyarsk", "Asia/Irkutsk", "Asia/Yakutsk", "Asia/Vladivostok", "Asia/Magadan", "Asia/Kamchatka"},
+	"SA": {"Asia/Riyadh"},
+	"SE": {"Europe/Stockholm"},
+	"SG": {"Asia/Singapore"},
+	"SI": {"Europe/Ljubljana"},
+	"SK": {"Europe/Bratislava"},
+	"TH": {"Asia/Bangkok"},
+	"TN": {"Africa/Tunis"},
+	"TR": {"Europe/Istanbul"},
+	"TW": {"Asia/Taipei"},
+	"TZ": {"Africa/Dar_es_Salaam"},
+	"UA": {"Europe/Kiev"},
+	"UG": {"Africa/Kampala"},
+	"US": {"America/New_York", "America/Chicago", "America/Denver", "America/Phoenix", "America/Los_Angeles", "America/Anchorage", "Pacific/Honolulu"},
+	"UY": {"America/Montevideo"},
+	"VE": {"America/Caracas"},
+	"VN": {"Asia/Ho_Chi_Minh"},
+	"ZA": {"Africa/Johannesburg"},
+}
+
+func lookupCountryZones(code string) error {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	zones, ok := countryZones[code]
+	if !ok {
+		return output.PrintError("not_found", fmt.Sprintf("No timezone data for country: %s", code),
+			map[string]string{"expected": "ISO 3166-1 alpha-2 code, e.g. US, GB, JP"})
+	}
+
+	return output.Print(CountryZones{
+		Country: code,
+		Primary: zones[0],
+		Zones:   zones,
+		Multi:   len(zones) > 1,
+	})
+}
+
 // knownTimezones is the list of IANA timezone names from Go's time package.
 var knownTimezones = []string{
 	"Africa/Abidjan", "Africa/Accra", "Africa/Addis_Ababa", "Africa/Algiers",
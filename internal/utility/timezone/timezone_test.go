@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewCmd(t *testing.T) {
@@ -22,7 +24,7 @@ func TestNewCmd(t *testing.T) {
 	for _, s := range cmd.Commands() {
 		subs[s.Use] = true
 	}
-	for _, name := range []string{"get [timezone]", "ip [ip-address]", "list"} {
+	for _, name := range []string{"get [timezone]", "ip [ip-address]", "list", "parse [datetime]", "country [iso-code]", "conflict", "now [zones...]", "convert [datetime]", "overlap"} {
 		if !subs[name] {
 			t.Errorf("missing subcommand %q", name)
 		}
@@ -82,6 +84,48 @@ func TestIPCmd(t *testing.T) {
 	}
 }
 
+func TestPublicIP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("203.0.113.7\n"))
+	}))
+	defer srv.Close()
+
+	oldURL := publicIPURL
+	publicIPURL = srv.URL
+	defer func() { publicIPURL = oldURL }()
+
+	ip, err := publicIP()
+	if err != nil {
+		t.Fatalf("publicIP failed: %v", err)
+	}
+	if ip != "203.0.113.7" {
+		t.Errorf("ip = %q, want 203.0.113.7", ip)
+	}
+}
+
+func TestIPCmdSelfOffline(t *testing.T) {
+	oldURL := publicIPURL
+	publicIPURL = "http://127.0.0.1:1"
+	defer func() { publicIPURL = oldURL }()
+
+	// Discovery fails, so the local timezone is reported instead
+	cmd := newIPCmd()
+	cmd.SetArgs([]string{"--self"})
+	if err := cmd.Execute(); err != nil {
+		t.Errorf("ip --self fallback failed: %v", err)
+	}
+}
+
+func TestIPCmdSelfWithAddress(t *testing.T) {
+	cmd := newIPCmd()
+	cmd.SetArgs([]string{"8.8.8.8", "--self"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected error combining an IP address with --self")
+	}
+}
+
 func TestListCmd(t *testing.T) {
 	// list command now uses built-in timezone list, no HTTP needed
 	cmd := newListCmd()
@@ -141,14 +185,38 @@ func TestFetchTimezoneByIPParseError(t *testing.T) {
 
 func TestGetTimezoneLocalUTC(t *testing.T) {
 	// UTC should always work
-	err := getTimezoneLocal("UTC")
+	err := getTimezoneLocal("UTC", "", "", "", false)
 	if err != nil {
 		t.Errorf("getTimezoneLocal(UTC) failed: %v", err)
 	}
 }
 
+func TestDayOfWeek(t *testing.T) {
+	tests := []struct {
+		day       time.Weekday
+		weekStart string
+		want      int
+	}{
+		{time.Sunday, "sunday", 0},
+		{time.Monday, "sunday", 1},
+		{time.Saturday, "sunday", 6},
+		{time.Sunday, "monday", 7},
+		{time.Monday, "monday", 1},
+		{time.Saturday, "monday", 6},
+	}
+	for _, tt := range tests {
+		if got := dayOfWeek(tt.day, tt.weekStart); got != tt.want {
+			t.Errorf("dayOfWeek(%v, %s) = %d, want %d", tt.day, tt.weekStart, got, tt.want)
+		}
+	}
+
+	if err := getTimezoneLocal("UTC", "", "", "friday", false); err == nil {
+		t.Error("expected error for unsupported week start")
+	}
+}
+
 func TestGetTimezoneLocalInvalid(t *testing.T) {
-	err := getTimezoneLocal("Not/A/Real/Zone")
+	err := getTimezoneLocal("Not/A/Real/Zone", "", "", "", false)
 	if err == nil {
 		t.Error("expected error for invalid timezone, got nil")
 	}
@@ -160,3 +228,332 @@ func TestListTimezonesOutput(t *testing.T) {
 		t.Errorf("listTimezones failed: %v", err)
 	}
 }
+
+func TestParseDateTime(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantUnix   int64
+		wantLayout string
+	}{
+		{"2024-01-15T07:30:45Z", 1705303845, time.RFC3339Nano},
+		{"2024-01-15 07:30", 1705303800, "2006-01-02 15:04"},
+		{"01/15/2024", 1705276800, "01/02/2006"},
+		{"Mon, 15 Jan 2024 07:30:45 +0000", 1705303845, time.RFC1123Z},
+		{"Jan 15 2024 7:30am", 1705303800, "Jan 2 2006 3:04pm"},
+	}
+	for _, tt := range tests {
+		got, layout, err := parseDateTime(tt.input, parseLayouts, time.UTC)
+		if err != nil {
+			t.Errorf("parseDateTime(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got.Unix() != tt.wantUnix {
+			t.Errorf("parseDateTime(%q) = %d, want %d", tt.input, got.Unix(), tt.wantUnix)
+		}
+		if layout != tt.wantLayout {
+			t.Errorf("parseDateTime(%q) layout = %q, want %q", tt.input, layout, tt.wantLayout)
+		}
+	}
+}
+
+func TestParseDateTimeNoMatch(t *testing.T) {
+	_, _, err := parseDateTime("not a date", parseLayouts, time.UTC)
+	if err == nil {
+		t.Error("expected error for unparseable input, got nil")
+	}
+}
+
+func TestParseCmdCustomLayout(t *testing.T) {
+	cmd := newParseCmd()
+	cmd.SetArgs([]string{"15.01.2024", "--layout", "02.01.2006"})
+	if err := cmd.Execute(); err != nil {
+		t.Errorf("parse command failed: %v", err)
+	}
+}
+
+func TestConvertTime(t *testing.T) {
+	from, _ := time.LoadLocation("America/New_York")
+	to, _ := time.LoadLocation("Asia/Tokyo")
+	instant := time.Date(2024, 1, 15, 9, 0, 0, 0, from)
+
+	c := convertTime("2024-01-15 09:00", instant, from, to, false)
+	if c.From.DateTime != "2024-01-15T09:00:00-05:00" {
+		t.Errorf("From.DateTime = %q", c.From.DateTime)
+	}
+	if c.To.DateTime != "2024-01-15T23:00:00+09:00" || c.To.UTCOffset != "+09:00" {
+		t.Errorf("To = %+v", c.To)
+	}
+	if c.UnixTime != nil {
+		t.Error("expected no unixtime without --epoch-out")
+	}
+
+	c = convertTime("2024-01-15 09:00", instant, from, to, true)
+	if c.UnixTime == nil || *c.UnixTime != 1705327200 {
+		t.Errorf("UnixTime = %v, want 1705327200", c.UnixTime)
+	}
+}
+
+func TestConvertCmdFromEpoch(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{[]string{"--from-epoch", "1717200000", "--to", "Asia/Tokyo"}, false},
+		{[]string{"--from-epoch-ms", "1717200000000", "--to", "Asia/Tokyo"}, false},
+		{[]string{"2024-06-01 00:00", "--from-epoch", "1717200000"}, true},
+		{[]string{"--from-epoch", "1", "--from-epoch-ms", "1000"}, true},
+		{[]string{}, true},
+	}
+	for _, tt := range tests {
+		cmd := newConvertCmd()
+		cmd.SetArgs(tt.args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		if err := cmd.Execute(); (err != nil) != tt.wantErr {
+			t.Errorf("convert %v: err = %v, wantErr %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestFormatDisplay(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"12h", "3:04 PM"},
+		{"24h", "15:04"},
+		{"kitchen", "3:04PM"},
+		{"rfc3339", "2024-01-15T15:04:05Z"},
+		{"2006/01/02", "2024/01/15"},
+	}
+	for _, tt := range tests {
+		got := formatDisplay(ts, tt.format)
+		if got != tt.want {
+			t.Errorf("formatDisplay(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestCountryZonesValid(t *testing.T) {
+	for code, zones := range countryZones {
+		if len(zones) == 0 {
+			t.Errorf("country %s has no zones", code)
+		}
+		for _, z := range zones {
+			if _, err := time.LoadLocation(z); err != nil {
+				t.Errorf("country %s: invalid zone %q", code, z)
+			}
+		}
+	}
+}
+
+func TestLookupCountryZones(t *testing.T) {
+	if err := lookupCountryZones("us"); err != nil {
+		t.Errorf("lookupCountryZones(us) failed: %v", err)
+	}
+	if err := lookupCountryZones("XX"); err == nil {
+		t.Error("expected error for unknown country, got nil")
+	}
+}
+
+func TestWorkingHoursContains(t *testing.T) {
+	london, err := parseWorkingHours("Europe/London:09:00-17:00")
+	if err != nil {
+		t.Fatalf("parseWorkingHours failed: %v", err)
+	}
+	night, err := parseWorkingHours("UTC:22:00-06:00")
+	if err != nil {
+		t.Fatalf("parseWorkingHours failed: %v", err)
+	}
+
+	// 2024-06-01 14:00 New York = 19:00 London (BST) = 18:00 UTC
+	ny, _ := time.LoadLocation("America/New_York")
+	slot := time.Date(2024, 6, 1, 14, 0, 0, 0, ny)
+	if london.contains(slot) {
+		t.Error("19:00 London should be outside 09:00-17:00")
+	}
+	if !london.contains(slot.Add(-6 * time.Hour)) {
+		t.Error("13:00 London should be within 09:00-17:00")
+	}
+	if night.contains(slot) {
+		t.Error("18:00 UTC should be outside 22:00-06:00")
+	}
+	if !night.contains(time.Date(2024, 6, 1, 3, 0, 0, 0, time.UTC)) {
+		t.Error("03:00 UTC should be within wrapping range 22:00-06:00")
+	}
+}
+
+func TestMeetingSlots(t *testing.T) {
+	ny, _ := parseWorkingHours("America/New_York:09:00-17:00")
+	ldn, _ := parseWorkingHours("Europe/London:09:00-17:00")
+	from := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	// NY 09:00 EDT is 13:00 UTC, London 17:00 BST is 16:00 UTC
+	ranges := overlapRanges(from, from.AddDate(0, 0, 1), []workingHours{ny, ldn})
+	if len(ranges) != 1 {
+		t.Fatalf("ranges = %d, want 1", len(ranges))
+	}
+	if got := ranges[0].Start.Format("15:04"); got != "13:00" {
+		t.Errorf("start = %s, want 13:00", got)
+	}
+	if got := ranges[0].End.Format("15:04"); got != "16:00" {
+		t.Errorf("end = %s, want 16:00", got)
+	}
+
+	tests := []struct {
+		granularity, duration time.Duration
+		want                  []string
+	}{
+		{30 * time.Minute, 30 * time.Minute, []string{"13:00", "13:30", "14:00", "14:30", "15:00", "15:30"}},
+		{30 * time.Minute, time.Hour, []string{"13:00", "13:30", "14:00", "14:30", "15:00"}},
+		{time.Hour, 90 * time.Minute, []string{"13:00", "14:00"}},
+		{30 * time.Minute, 4 * time.Hour, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, s := range meetingSlots(ranges, tt.granularity, tt.duration) {
+			got = append(got, s.Start.Format("15:04"))
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("meetingSlots(%v, %v) = %v, want %v", tt.granularity, tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestParseWorkingHoursInvalid(t *testing.T) {
+	for _, spec := range []string{"Europe/London", "Nowhere/City:09:00-17:00", "UTC:9-5", "UTC:09:00"} {
+		if _, err := parseWorkingHours(spec); err == nil {
+			t.Errorf("parseWorkingHours(%q) expected error", spec)
+		}
+	}
+}
+
+func TestCompareToLocal(t *testing.T) {
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	ny, _ := time.LoadLocation("America/New_York")
+	instant := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		remote, local *time.Location
+		wantHours     float64
+		wantDesc      string
+	}{
+		{kolkata, time.UTC, 5.5, "5h30m ahead of local"},
+		{ny, time.UTC, -5, "5h behind local"},
+		{time.UTC, time.UTC, 0, "same time as local"},
+	}
+	for _, tt := range tests {
+		got := compareToLocal(instant.In(tt.remote), instant.In(tt.local))
+		if got.DifferenceHours != tt.wantHours {
+			t.Errorf("%s vs %s: difference = %v, want %v", tt.remote, tt.local, got.DifferenceHours, tt.wantHours)
+		}
+		if got.Description != tt.wantDesc {
+			t.Errorf("%s vs %s: description = %q, want %q", tt.remote, tt.local, got.Description, tt.wantDesc)
+		}
+	}
+}
+
+func TestWorldClock(t *testing.T) {
+	instant := time.Date(2024, 1, 15, 23, 30, 0, 0, time.UTC)
+	clocks, err := worldClock([]string{"UTC", "Asia/Tokyo"}, instant)
+	if err != nil {
+		t.Fatalf("worldClock failed: %v", err)
+	}
+	if clocks[1].LocalTime != "08:30" || clocks[1].UTCOffset != "+09:00" || clocks[1].Day != "Tue Jan 16" {
+		t.Errorf("unexpected Tokyo clock: %+v", clocks[1])
+	}
+
+	if _, err := worldClock([]string{"Nowhere/City"}, instant); err == nil {
+		t.Error("expected error for unknown zone, got nil")
+	}
+}
+
+func TestSortClocks(t *testing.T) {
+	zones := []string{"America/New_York", "Asia/Tokyo", "UTC", "Europe/Berlin"}
+	instant := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		by   string
+		want string
+	}{
+		{"", "America/New_York,Asia/Tokyo,UTC,Europe/Berlin"},
+		{"offset", "Asia/Tokyo,Europe/Berlin,UTC,America/New_York"},
+		{"time", "America/New_York,UTC,Europe/Berlin,Asia/Tokyo"},
+		{"name", "America/New_York,Asia/Tokyo,Europe/Berlin,UTC"},
+	}
+	for _, tt := range tests {
+		clocks, err := worldClock(zones, instant)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sortClocks(clocks, tt.by); err != nil {
+			t.Fatalf("sortClocks(%q): %v", tt.by, err)
+		}
+		var got []string
+		for _, c := range clocks {
+			got = append(got, c.Zone)
+		}
+		if strings.Join(got, ",") != tt.want {
+			t.Errorf("sortClocks(%q) = %v, want %s", tt.by, got, tt.want)
+		}
+	}
+
+	if err := sortClocks(nil, "size"); err == nil {
+		t.Error("expected error for unsupported sort")
+	}
+}
+
+func TestWriteClockTable(t *testing.T) {
+	instant := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	clocks, _ := worldClock([]string{"UTC", "America/Los_Angeles"}, instant)
+
+	var b strings.Builder
+	if err := writeClockTable(&b, clocks); err != nil {
+		t.Fatalf("writeClockTable failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d lines", len(lines))
+	}
+	// Columns are aligned, so the offset column starts at the same position
+	col := strings.Index(lines[0], "OFFSET")
+	if !strings.HasPrefix(lines[2][col:], "-08:00") {
+		t.Errorf("offset column misaligned:\n%s", b.String())
+	}
+}
+
+func TestLocalizeNames(t *testing.T) {
+	jan := time.Date(2024, 1, 15, 15, 4, 0, 0, time.UTC) // a Monday
+	may := time.Date(2024, 5, 6, 15, 4, 0, 0, time.UTC)  // also a Monday
+	tests := []struct {
+		locale string
+		ts     time.Time
+		layout string
+		want   string
+	}{
+		{"es", jan, defaultLocaleFormat, "lunes, 15 enero 2024 15:04"},
+		{"de", jan, "Mon 2 Jan", "Mo 15 Jan"},
+		{"fr", jan, "Monday 2 January", "lundi 15 janvier"},
+		{"ja", jan, "January 2 (Monday)", "1月 15 (月曜日)"},
+		// May is both the full and the short English name
+		{"fr", may, "2 Jan", "6 mai"},
+		{"es", may, "Mon 2 Jan 2006", "lun 6 may 2024"},
+		{"de", may, "January", "Mai"},
+		// Literal text that isn't a layout element stays English
+		{"fr", may, "Mayday Monet: 2 January", "Mayday Monet: 6 mai"},
+		{"de", jan, "3:04 PM MST", "3:04 PM UTC"},
+	}
+	for _, tt := range tests {
+		got := localeTable[tt.locale].format(tt.ts, tt.layout)
+		if got != tt.want {
+			t.Errorf("format(%s, %q) = %q, want %q", tt.locale, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestGetTimezoneLocalUnsupportedLocale(t *testing.T) {
+	if err := getTimezoneLocal("UTC", "", "xx", "", false); err == nil {
+		t.Error("expected error for unsupported locale, got nil")
+	}
+}
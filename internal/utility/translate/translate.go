@@ -4,11 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
+	"github.com/mozillazg/go-unidecode"
 	"github.com/spf13/cobra"
 
 	"github.com/unstablemind/pocket/pkg/output"
@@ -28,6 +34,11 @@ type Translation struct {
 	SourceLang     string  `json:"source_lang"`
 	TargetLang     string  `json:"target_lang"`
 	Match          float64 `json:"match,omitempty"`
+	Romanized      string  `json:"romanized,omitempty"`
+	// Attempt is set with --source-lang-override-on-fail: "auto" or "override"
+	Attempt    string `json:"attempt,omitempty"`
+	Characters int    `json:"characters,omitempty"`
+	Warning    string `json:"warning,omitempty"`
 }
 
 // Language represents a supported language
@@ -45,145 +56,1012 @@ func NewCmd() *cobra.Command {
 
 	cmd.AddCommand(newTextCmd())
 	cmd.AddCommand(newLanguagesCmd())
+	cmd.AddCommand(newBatchCmd())
+	cmd.AddCommand(newDetectCmd())
 
 	return cmd
 }
 
 func newTextCmd() *cobra.Command {
 	var fromLang, toLang string
+	var keep []string
+	var keepFile string
+	var romanize bool
+	var concurrency int
+	var overrideOnFail bool
+	var overrideLang string
+	var minConfidence float64
+	var strict bool
 
 	cmd := &cobra.Command{
 		Use:   "text [text]",
 		Short: "Translate text between languages",
-		Args:  cobra.MinimumNArgs(1),
+		Long: `Translate text from --from to --to. Use --to all (or --to common) to translate
+into every language listed by the languages command at once, returning one
+translation per language.
+
+Language codes may be region-qualified (pt-BR, en-GB, zh-TW) and are passed
+through as given; the languages command --full lists the common variants.
+
+Use --from auto to let MyMemory detect the source language. Detection can
+misfire on short strings, so --source-lang-override-on-fail retries a poor
+auto match with --override-lang and keeps whichever scores higher.
+--min-confidence detects the source language first and adds a warning when
+the detection is below the threshold (or has no confidence score); with
+--strict it fails instead, without translating.
+
+MyMemory rejects any single request over 500 bytes of UTF-8 (about 160 CJK
+characters). Each line of multi-line input is sent as its own request, so a
+warning is added when any line is over the limit; --strict fails instead
+without calling the API.
+
+--romanize adds a Latin transliteration of the result. It is omitted for
+Japanese, because the transliterator reads kanji as Chinese.`,
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			text := strings.Join(args, " ")
 
-			// Build the langpair as "from|to". The pipe must NOT be percent-encoded
-			// because the MyMemory API requires a literal pipe separator.
-			// url.QueryEscape would encode | to %7C, breaking the API call.
-			langpair := fmt.Sprintf("%s|%s", url.QueryEscape(fromLang), url.QueryEscape(toLang))
-			reqURL := fmt.Sprintf("%s/get?q=%s&langpair=%s",
-				baseURL,
-				url.QueryEscape(text),
-				langpair)
-
-			resp, err := doRequest(reqURL)
-			if err != nil {
+			var err error
+			if fromLang, err = validLangCode(fromLang); err != nil {
+				return err
+			}
+			if toLang != "all" && toLang != "common" {
+				if toLang, err = validLangCode(toLang); err != nil {
+					return err
+				}
+			}
+			if overrideLang, err = validLangCode(overrideLang); err != nil {
 				return err
 			}
-			defer resp.Body.Close()
 
-			var data struct {
-				ResponseStatus int `json:"responseStatus"`
-				ResponseData   struct {
-					TranslatedText string  `json:"translatedText"`
-					Match          float64 `json:"match"`
-				} `json:"responseData"`
-				ResponseDetails string `json:"responseDetails"`
-				Matches         []struct {
-					Translation string  `json:"translation"`
-					Match       float64 `json:"match"`
-					Quality     any     `json:"quality"`
-				} `json:"matches"`
+			terms, err := loadKeepTerms(keep, keepFile)
+			if err != nil {
+				return output.PrintError("read_failed", err.Error(), nil)
 			}
 
-			if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-				return output.PrintError("parse_failed", err.Error(), nil)
+			if overrideOnFail && fromLang != "auto" {
+				return output.PrintError("invalid_input", "--source-lang-override-on-fail requires --from auto", nil)
+			}
+			if minConfidence < 0 || minConfidence > 1 {
+				return output.PrintError("invalid_input", "--min-confidence must be between 0 and 1", nil)
+			}
+			if minConfidence > 0 && fromLang != "auto" {
+				return output.PrintError("invalid_input", "--min-confidence requires --from auto", nil)
 			}
 
-			if data.ResponseStatus != 200 {
-				msg := "Translation failed"
-				if data.ResponseDetails != "" {
-					msg = data.ResponseDetails
+			length := checkLength(text)
+			warning := ""
+			if length.LongestSegmentBytes > maxQueryBytes {
+				warning = fmt.Sprintf("A segment is %d bytes, over MyMemory's %d-byte limit per request; %s",
+					length.LongestSegmentBytes, maxQueryBytes, chunkingHint)
+				if strict {
+					return output.PrintError("too_long", warning, length)
 				}
-				return output.PrintError("api_error", msg, nil)
 			}
 
-			// Select the best translation from matches. The responseData.translatedText
-			// can sometimes return garbage from low-quality community contributions.
-			// We look through all matches and pick the most frequently occurring
-			// translation among those with the highest match score.
-			translatedText := data.ResponseData.TranslatedText
-			matchScore := data.ResponseData.Match
+			if minConfidence > 0 {
+				d, err := detectLanguage(text)
+				if err != nil {
+					return printError(err)
+				}
+				if msg := lowConfidence(d, minConfidence); msg != "" {
+					if strict {
+						return output.PrintError("low_confidence_detection", msg, d)
+					}
+					warning = joinWarnings(warning, msg)
+				}
+			}
 
-			if len(data.Matches) > 1 {
-				translatedText, matchScore = bestTranslation(data.Matches)
+			if toLang == "all" || toLang == "common" {
+				translations, errs := translateAll(text, fromLang, terms, concurrency)
+				if romanize {
+					for i := range translations {
+						translations[i].Romanized = romanizeText(translations[i].TranslatedText, translations[i].TargetLang)
+					}
+				}
+				response := map[string]any{
+					"source_text":  text,
+					"source_lang":  fromLang,
+					"characters":   length.Characters,
+					"count":        len(translations),
+					"failed":       len(errs),
+					"translations": translations,
+					"errors":       errs,
+				}
+				if warning != "" {
+					response["warning"] = warning
+				}
+				return output.Print(response)
 			}
 
-			translation := Translation{
-				SourceText:     text,
-				TranslatedText: translatedText,
-				SourceLang:     fromLang,
-				TargetLang:     toLang,
-				Match:          matchScore,
+			var translation Translation
+			if overrideOnFail {
+				translation, err = translateWithOverride(text, overrideLang, toLang, terms)
+			} else {
+				translation, err = translateKeeping(text, fromLang, toLang, terms)
+			}
+			if err != nil {
+				return printError(err)
+			}
+			if romanize {
+				translation.Romanized = romanizeText(translation.TranslatedText, translation.TargetLang)
 			}
+			translation.Characters = length.Characters
+			translation.Warning = warning
 
 			return output.Print(translation)
 		},
 	}
 
-	cmd.Flags().StringVarP(&fromLang, "from", "f", "en", "Source language code (e.g., en, es, fr)")
-	cmd.Flags().StringVarP(&toLang, "to", "t", "es", "Target language code (e.g., en, es, fr)")
+	cmd.Flags().StringVarP(&fromLang, "from", "f", "en", "Source language code (e.g., en, es, pt-BR), or auto")
+	cmd.Flags().StringVarP(&toLang, "to", "t", "es", "Target language code (e.g., en, es, pt-BR), or all")
+	cmd.Flags().BoolVar(&romanize, "romanize", false, "Include a Latin transliteration of non-Latin output (not for ja, whose kanji would be read as Chinese)")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "c", 4, "Maximum concurrent requests with --to all")
+	cmd.Flags().BoolVar(&overrideOnFail, "source-lang-override-on-fail", false, "Retry a poor --from auto match with --override-lang and keep the better result")
+	cmd.Flags().StringVar(&overrideLang, "override-lang", "en", "Source language used for the retry")
+	cmd.Flags().Float64Var(&minConfidence, "min-confidence", 0, "With --from auto, warn when detection confidence (0-1) is below this")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail instead of warning on an over-length request or a low-confidence detection")
+	addKeepFlags(cmd, &keep, &keepFile)
+
+	return cmd
+}
+
+// maxQueryBytes is the longest text MyMemory accepts in one request
+const maxQueryBytes = 500
+
+// chunkingHint tells callers how to get over-long text under the limit
+const chunkingHint = "split it across lines (each line is translated separately) or pass pieces to the batch command"
+
+// LengthReport is the size of a translation request, measured before sending
+type LengthReport struct {
+	Characters          int `json:"characters"`
+	LongestSegmentBytes int `json:"longest_segment_bytes"`
+	LimitBytes          int `json:"limit_bytes"`
+}
+
+// checkLength measures text the way translateText will send it: as a whole,
+// or line by line for multi-line input
+func checkLength(text string) LengthReport {
+	report := LengthReport{Characters: utf8.RuneCountInString(text), LimitBytes: maxQueryBytes}
+	for _, line := range strings.Split(text, "\n") {
+		if n := len(strings.TrimSpace(line)); n > report.LongestSegmentBytes {
+			report.LongestSegmentBytes = n
+		}
+	}
+	return report
+}
+
+// LanguageError describes a target language that could not be translated into
+type LanguageError struct {
+	TargetLang string `json:"target_lang"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+// translateAll translates text into every common language other than the
+// source, with bounded concurrency and rate-limit retries. Results are in
+// commonLanguages order.
+func translateAll(text, fromLang string, terms []string, concurrency int) ([]Translation, []LanguageError) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var targets []string
+	for _, l := range commonLanguages {
+		if l.Code != fromLang {
+			targets = append(targets, l.Code)
+		}
+	}
+
+	masked, originals := maskTerms(text, terms)
+	results := make([]Translation, len(targets))
+	errs := make([]error, len(targets))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i], errs[i] = translateWithRetry(masked, fromLang, target)
+		}(i, target)
+	}
+	wg.Wait()
+
+	translations := []Translation{}
+	failures := []LanguageError{}
+	for i, target := range targets {
+		if err := errs[i]; err != nil {
+			le := LanguageError{TargetLang: target, Code: "fetch_failed", Message: err.Error()}
+			if te, ok := err.(*translateError); ok {
+				le.Code = te.Code
+			}
+			failures = append(failures, le)
+			continue
+		}
+		tr := results[i]
+		tr.SourceText = text
+		tr.TranslatedText = unmaskTerms(tr.TranslatedText, originals)
+		translations = append(translations, tr)
+	}
+	return translations, failures
+}
+
+// apiMatch is a single candidate translation from the MyMemory matches array
+type apiMatch struct {
+	Translation string  `json:"translation"`
+	Match       float64 `json:"match"`
+	Quality     any     `json:"quality"`
+}
+
+// translateError is a translation failure with a machine-readable code
+type translateError struct {
+	Code    string
+	Message string
+}
+
+func (e *translateError) Error() string {
+	return e.Message
+}
+
+// printError outputs err using its translateError code when available
+func printError(err error) error {
+	if te, ok := err.(*translateError); ok {
+		return output.PrintError(te.Code, te.Message, nil)
+	}
+	return output.PrintError("fetch_failed", err.Error(), nil)
+}
+
+// translateText translates text without printing, so callers can decide how
+// to report failures. MyMemory collapses whitespace and drops newlines, so
+// multi-line input is translated line by line and reassembled with each
+// line's surrounding whitespace intact. The reported match is the lowest
+// across lines.
+func translateText(text, fromLang, toLang string) (Translation, error) {
+	if !strings.Contains(text, "\n") && strings.TrimSpace(text) == text {
+		return translateSegment(text, fromLang, toLang)
+	}
+
+	lines := strings.Split(text, "\n")
+	out := make([]string, len(lines))
+	matchScore := -1.0
+	for i, line := range lines {
+		core := strings.TrimSpace(line)
+		if core == "" {
+			out[i] = line
+			continue
+		}
+
+		tr, err := translateSegment(core, fromLang, toLang)
+		if err != nil {
+			return Translation{}, err
+		}
+
+		start := strings.Index(line, core)
+		out[i] = line[:start] + tr.TranslatedText + line[start+len(core):]
+		if matchScore < 0 || tr.Match < matchScore {
+			matchScore = tr.Match
+		}
+	}
+	if matchScore < 0 {
+		matchScore = 0
+	}
+
+	return Translation{
+		SourceText:     text,
+		TranslatedText: strings.Join(out, "\n"),
+		SourceLang:     fromLang,
+		TargetLang:     toLang,
+		Match:          matchScore,
+	}, nil
+}
+
+// translateSegment translates a single line via MyMemory
+func translateSegment(text, fromLang, toLang string) (Translation, error) {
+	// Build the langpair as "from|to". The pipe must NOT be percent-encoded
+	// because the MyMemory API requires a literal pipe separator.
+	// url.QueryEscape would encode | to %7C, breaking the API call.
+	source := fromLang
+	if source == "auto" {
+		source = "Autodetect"
+	}
+	langpair := fmt.Sprintf("%s|%s", url.QueryEscape(source), url.QueryEscape(toLang))
+	reqURL := fmt.Sprintf("%s/get?q=%s&langpair=%s",
+		baseURL,
+		url.QueryEscape(text),
+		langpair)
+
+	resp, err := doRequest(reqURL)
+	if err != nil {
+		return Translation{}, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		ResponseStatus int `json:"responseStatus"`
+		ResponseData   struct {
+			TranslatedText string  `json:"translatedText"`
+			Match          float64 `json:"match"`
+		} `json:"responseData"`
+		ResponseDetails string     `json:"responseDetails"`
+		Matches         []apiMatch `json:"matches"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return Translation{}, &translateError{Code: "parse_failed", Message: err.Error()}
+	}
+
+	if data.ResponseStatus != 200 {
+		msg := "Translation failed"
+		if data.ResponseDetails != "" {
+			msg = data.ResponseDetails
+		}
+		return Translation{}, &translateError{Code: "api_error", Message: msg}
+	}
+
+	// Select the best translation from matches. The responseData.translatedText
+	// can sometimes return garbage from low-quality community contributions.
+	// We look through all matches and pick the most frequently occurring
+	// translation among those with the highest match score.
+	translatedText := data.ResponseData.TranslatedText
+	matchScore := data.ResponseData.Match
+
+	if len(data.Matches) > 1 {
+		translatedText, matchScore = bestTranslation(data.Matches)
+	}
+
+	return Translation{
+		SourceText:     text,
+		TranslatedText: translatedText,
+		SourceLang:     fromLang,
+		TargetLang:     toLang,
+		Match:          matchScore,
+	}, nil
+}
+
+// Detection is the detected source language of a text. Confidence is nil
+// when MyMemory reports the language without a score.
+type Detection struct {
+	Text       string   `json:"text"`
+	Language   string   `json:"language"`
+	Confidence *float64 `json:"confidence,omitempty"`
+	Warning    string   `json:"warning,omitempty"`
+}
+
+// lowConfidence describes why d falls short of min, or returns "" when it
+// doesn't. A detection without a confidence score can't meet any threshold.
+func lowConfidence(d Detection, min float64) string {
+	if d.Confidence == nil {
+		return fmt.Sprintf("Detected %s without a confidence score, so it can't be checked against %.2f", d.Language, min)
+	}
+	if *d.Confidence < min {
+		return fmt.Sprintf("Detected %s with confidence %.2f, below %.2f", d.Language, *d.Confidence, min)
+	}
+	return ""
+}
+
+// joinWarnings combines two warnings, either of which may be empty
+func joinWarnings(a, b string) string {
+	if a == "" || b == "" {
+		return a + b
+	}
+	return a + "; " + b
+}
+
+func newDetectCmd() *cobra.Command {
+	var minConfidence float64
+	var strict bool
+
+	cmd := &cobra.Command{
+		Use:   "detect [text]",
+		Short: "Detect the language of text",
+		Long: `Detect the language of text using MyMemory's autodetection. The confidence
+(0-1) is included when MyMemory reports one. With --min-confidence, a detection
+below the threshold, or without a confidence, carries a
+low_confidence_detection warning, or fails with --strict.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if minConfidence < 0 || minConfidence > 1 {
+				return output.PrintError("invalid_input", "--min-confidence must be between 0 and 1", nil)
+			}
+
+			d, err := detectLanguage(strings.Join(args, " "))
+			if err != nil {
+				return printError(err)
+			}
+
+			if minConfidence > 0 {
+				if msg := lowConfidence(d, minConfidence); msg != "" {
+					if strict {
+						return output.PrintError("low_confidence_detection", msg, d)
+					}
+					d.Warning = "low_confidence_detection"
+				}
+			}
+
+			return output.Print(d)
+		},
+	}
+
+	cmd.Flags().Float64Var(&minConfidence, "min-confidence", 0, "Warn when detection confidence (0-1) is below this")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail instead of warning on low-confidence detection")
 
 	return cmd
 }
 
+// detectLanguage asks MyMemory to autodetect the language of text. The
+// detected language may be reported as a bare code or as an object with its
+// own confidence. The translation match score is not a detection confidence,
+// so without one Confidence stays nil.
+func detectLanguage(text string) (Detection, error) {
+	reqURL := fmt.Sprintf("%s/get?q=%s&langpair=Autodetect|en", baseURL, url.QueryEscape(text))
+
+	resp, err := doRequest(reqURL)
+	if err != nil {
+		return Detection{}, err
+	}
+	defer resp.Body.Close()
+
+	var data struct {
+		ResponseStatus int `json:"responseStatus"`
+		ResponseData   struct {
+			DetectedLanguage json.RawMessage `json:"detectedLanguage"`
+		} `json:"responseData"`
+		ResponseDetails string `json:"responseDetails"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return Detection{}, &translateError{Code: "parse_failed", Message: err.Error()}
+	}
+	if data.ResponseStatus != 200 {
+		msg := "Detection failed"
+		if data.ResponseDetails != "" {
+			msg = data.ResponseDetails
+		}
+		return Detection{}, &translateError{Code: "api_error", Message: msg}
+	}
+
+	d := Detection{Text: text}
+	var detected struct {
+		Language   string   `json:"language"`
+		Confidence *float64 `json:"confidence"`
+	}
+	if json.Unmarshal(data.ResponseData.DetectedLanguage, &d.Language) != nil &&
+		json.Unmarshal(data.ResponseData.DetectedLanguage, &detected) == nil {
+		d.Language = detected.Language
+		d.Confidence = detected.Confidence
+	}
+	if d.Language == "" {
+		return Detection{}, &translateError{Code: "detection_failed", Message: "Could not detect language"}
+	}
+	// Reduce region-qualified codes like "it-IT" to the base language
+	d.Language, _, _ = strings.Cut(d.Language, "-")
+	d.Language = strings.ToLower(d.Language)
+
+	return d, nil
+}
+
+// addKeepFlags registers the do-not-translate flags shared by text and batch
+func addKeepFlags(cmd *cobra.Command, keep *[]string, keepFile *string) {
+	cmd.Flags().StringSliceVar(keep, "keep", nil, "Terms to leave untranslated, e.g. \"Acme,Widget Pro\"")
+	cmd.Flags().StringVar(keepFile, "keep-file", "", "Glossary file with one do-not-translate term per line")
+}
+
+// loadKeepTerms merges --keep terms with those listed in keepFile
+func loadKeepTerms(keep []string, keepFile string) ([]string, error) {
+	terms := append([]string{}, keep...)
+	if keepFile != "" {
+		data, err := os.ReadFile(keepFile)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, strings.Split(string(data), "\n")...)
+	}
+
+	cleaned := terms[:0]
+	for _, t := range terms {
+		if t = strings.TrimSpace(t); t != "" {
+			cleaned = append(cleaned, t)
+		}
+	}
+	return cleaned, nil
+}
+
+// maskTerms replaces each protected term in text with an opaque placeholder
+// that translation engines leave alone. Longer terms are masked first so a
+// term containing another ("Widget Pro" vs "Widget") is protected whole.
+// It returns the masked text and the originals, indexed by placeholder number.
+func maskTerms(text string, terms []string) (string, []string) {
+	sorted := append([]string{}, terms...)
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	var originals []string
+	for _, term := range sorted {
+		if !strings.Contains(text, term) {
+			continue
+		}
+		text = strings.ReplaceAll(text, term, placeholder(len(originals)))
+		originals = append(originals, term)
+	}
+	return text, originals
+}
+
+// unmaskTerms restores the terms hidden by maskTerms
+func unmaskTerms(text string, originals []string) string {
+	for i, term := range originals {
+		text = strings.ReplaceAll(text, placeholder(i), term)
+	}
+	return text
+}
+
+func placeholder(i int) string {
+	return fmt.Sprintf("ZXK%dZX", i)
+}
+
+// translateKeeping translates text while leaving the given terms untouched
+func translateKeeping(text, fromLang, toLang string, terms []string) (Translation, error) {
+	masked, originals := maskTerms(text, terms)
+	tr, err := translateText(masked, fromLang, toLang)
+	if err != nil {
+		return tr, err
+	}
+	tr.SourceText = text
+	tr.TranslatedText = unmaskTerms(tr.TranslatedText, originals)
+	return tr, nil
+}
+
+// poorMatch is the match score below which an auto-detected translation is
+// retried with an explicit source language
+const poorMatch = 0.5
+
+// translateWithOverride translates with --from auto and, when that fails or
+// scores below poorMatch, retries from overrideLang. The higher-scoring
+// result wins; Attempt records which one.
+func translateWithOverride(text, overrideLang, toLang string, terms []string) (Translation, error) {
+	auto, autoErr := translateKeeping(text, "auto", toLang, terms)
+	if autoErr == nil && auto.Match >= poorMatch {
+		auto.Attempt = "auto"
+		return auto, nil
+	}
+
+	override, err := translateKeeping(text, overrideLang, toLang, terms)
+	if err != nil {
+		if autoErr != nil {
+			return Translation{}, autoErr
+		}
+		auto.Attempt = "auto"
+		return auto, nil
+	}
+	if autoErr == nil && auto.Match >= override.Match {
+		auto.Attempt = "auto"
+		return auto, nil
+	}
+	override.Attempt = "override"
+	return override, nil
+}
+
+// unromanizable lists target languages unidecode transliterates wrongly.
+// It reads every Han character with its Mandarin pronunciation, so Japanese
+// kanji come out as Chinese ("日本語" becomes "Ri Ben Yu", not "nihongo");
+// romanized is left out for these rather than print a misleading reading.
+var unromanizable = map[string]bool{"ja": true}
+
+// romanizeText returns a Latin transliteration of text in lang, or "" when
+// the text is already in Latin script and transliterating would add nothing,
+// or when lang is unromanizable
+func romanizeText(text, lang string) string {
+	if base, _, _ := strings.Cut(lang, "-"); unromanizable[strings.ToLower(base)] {
+		return ""
+	}
+	romanized := strings.TrimSpace(unidecode.Unidecode(text))
+	if romanized == strings.TrimSpace(text) {
+		return ""
+	}
+	return romanized
+}
+
+// rateLimitBackoff is the initial wait before retrying a rate-limited request
+var rateLimitBackoff = 2 * time.Second
+
+// BatchError describes a batch item that could not be translated
+type BatchError struct {
+	Index   int    `json:"index"`
+	Text    string `json:"text"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func newBatchCmd() *cobra.Command {
+	var fromLang, toLang, file string
+	var concurrency int
+	var keep []string
+	var keepFile string
+	var romanize bool
+
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Translate a JSON array of strings",
+		Long: `Translate every string in a JSON array file (or "-" for stdin) and return a
+parallel array of translations in the same order. Duplicate strings are only
+sent once, rate-limited requests are retried with backoff, and failures are
+reported per item without aborting the batch.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if fromLang, err = validLangCode(fromLang); err != nil {
+				return err
+			}
+			if toLang, err = validLangCode(toLang); err != nil {
+				return err
+			}
+
+			var data []byte
+			if file == "-" {
+				data, err = io.ReadAll(os.Stdin)
+			} else {
+				data, err = os.ReadFile(file)
+			}
+			if err != nil {
+				return output.PrintError("read_failed", err.Error(), nil)
+			}
+
+			var texts []string
+			if err := json.Unmarshal(data, &texts); err != nil {
+				return output.PrintError("parse_failed", "Input must be a JSON array of strings: "+err.Error(), nil)
+			}
+
+			terms, err := loadKeepTerms(keep, keepFile)
+			if err != nil {
+				return output.PrintError("read_failed", err.Error(), nil)
+			}
+
+			masked := make([]string, len(texts))
+			originals := make([][]string, len(texts))
+			for i, t := range texts {
+				masked[i], originals[i] = maskTerms(t, terms)
+			}
+
+			translations, errs := translateBatch(masked, fromLang, toLang, concurrency)
+			for i := range translations {
+				translations[i].SourceText = texts[i]
+				translations[i].TranslatedText = unmaskTerms(translations[i].TranslatedText, originals[i])
+				if romanize {
+					translations[i].Romanized = romanizeText(translations[i].TranslatedText, translations[i].TargetLang)
+				}
+			}
+			for i := range errs {
+				errs[i].Text = texts[errs[i].Index]
+			}
+
+			return output.Print(map[string]any{
+				"source_lang":  fromLang,
+				"target_lang":  toLang,
+				"count":        len(translations),
+				"failed":       len(errs),
+				"translations": translations,
+				"errors":       errs,
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&fromLang, "from", "f", "en", "Source language code (e.g., en, es, pt-BR), or auto")
+	cmd.Flags().StringVarP(&toLang, "to", "t", "es", "Target language code (e.g., en, es, pt-BR)")
+	cmd.Flags().StringVar(&file, "file", "", "Path to a JSON array of strings, or - for stdin (required)")
+	cmd.Flags().IntVarP(&concurrency, "concurrency", "c", 4, "Maximum concurrent requests")
+	cmd.Flags().BoolVar(&romanize, "romanize", false, "Include a Latin transliteration of non-Latin output (not for ja, whose kanji would be read as Chinese)")
+	addKeepFlags(cmd, &keep, &keepFile)
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// translateBatch translates texts with bounded concurrency, returning results
+// in input order. Identical strings are translated once and shared.
+func translateBatch(texts []string, fromLang, toLang string, concurrency int) ([]Translation, []BatchError) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	type result struct {
+		translation Translation
+		err         error
+	}
+
+	// Translate each distinct string once. The distinct texts are collected
+	// before any worker starts so results never share a map being iterated.
+	var distinct []string
+	seen := make(map[string]bool)
+	for _, t := range texts {
+		if !seen[t] {
+			seen[t] = true
+			distinct = append(distinct, t)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]result, len(distinct))
+	sem := make(chan struct{}, concurrency)
+	for _, text := range distinct {
+		wg.Add(1)
+		go func(text string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			tr, err := translateWithRetry(text, fromLang, toLang)
+			mu.Lock()
+			results[text] = result{translation: tr, err: err}
+			mu.Unlock()
+		}(text)
+	}
+	wg.Wait()
+
+	translations := make([]Translation, len(texts))
+	errs := []BatchError{}
+	for i, text := range texts {
+		r := results[text]
+		if r.err != nil {
+			translations[i] = Translation{SourceText: text, SourceLang: fromLang, TargetLang: toLang}
+			be := BatchError{Index: i, Text: text, Code: "fetch_failed", Message: r.err.Error()}
+			if te, ok := r.err.(*translateError); ok {
+				be.Code = te.Code
+			}
+			errs = append(errs, be)
+			continue
+		}
+		translations[i] = r.translation
+	}
+
+	return translations, errs
+}
+
+// translateWithRetry retries rate-limited translations with exponential backoff
+func translateWithRetry(text, fromLang, toLang string) (Translation, error) {
+	const maxAttempts = 3
+	backoff := rateLimitBackoff
+
+	var tr Translation
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		tr, err = translateText(text, fromLang, toLang)
+		te, ok := err.(*translateError)
+		if err == nil || !ok || te.Code != "rate_limited" || attempt == maxAttempts {
+			break
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+	return tr, err
+}
+
+// commonLanguages is the curated set listed by the languages command and used
+// by text --to all. MyMemory supports many more.
+var commonLanguages = []Language{
+	{Code: "en", Name: "English"},
+	{Code: "es", Name: "Spanish"},
+	{Code: "fr", Name: "French"},
+	{Code: "de", Name: "German"},
+	{Code: "it", Name: "Italian"},
+	{Code: "pt", Name: "Portuguese"},
+	{Code: "ru", Name: "Russian"},
+	{Code: "zh", Name: "Chinese (Simplified)"},
+	{Code: "ja", Name: "Japanese"},
+	{Code: "ko", Name: "Korean"},
+	{Code: "ar", Name: "Arabic"},
+	{Code: "hi", Name: "Hindi"},
+	{Code: "nl", Name: "Dutch"},
+	{Code: "pl", Name: "Polish"},
+	{Code: "tr", Name: "Turkish"},
+	{Code: "vi", Name: "Vietnamese"},
+	{Code: "th", Name: "Thai"},
+	{Code: "id", Name: "Indonesian"},
+	{Code: "ms", Name: "Malay"},
+	{Code: "sv", Name: "Swedish"},
+	{Code: "da", Name: "Danish"},
+	{Code: "no", Name: "Norwegian"},
+	{Code: "fi", Name: "Finnish"},
+	{Code: "el", Name: "Greek"},
+	{Code: "he", Name: "Hebrew"},
+	{Code: "cs", Name: "Czech"},
+	{Code: "ro", Name: "Romanian"},
+	{Code: "hu", Name: "Hungarian"},
+	{Code: "uk", Name: "Ukrainian"},
+	{Code: "bn", Name: "Bengali"},
+}
+
+// moreLanguages are further languages MyMemory accepts, listed by
+// languages --full alongside commonLanguages
+var moreLanguages = []Language{
+	{Code: "af", Name: "Afrikaans"},
+	{Code: "sq", Name: "Albanian"},
+	{Code: "am", Name: "Amharic"},
+	{Code: "hy", Name: "Armenian"},
+	{Code: "az", Name: "Azerbaijani"},
+	{Code: "eu", Name: "Basque"},
+	{Code: "be", Name: "Belarusian"},
+	{Code: "bs", Name: "Bosnian"},
+	{Code: "bg", Name: "Bulgarian"},
+	{Code: "my", Name: "Burmese"},
+	{Code: "ca", Name: "Catalan"},
+	{Code: "zh-TW", Name: "Chinese (Traditional)"},
+	{Code: "hr", Name: "Croatian"},
+	{Code: "eo", Name: "Esperanto"},
+	{Code: "et", Name: "Estonian"},
+	{Code: "fil", Name: "Filipino"},
+	{Code: "gl", Name: "Galician"},
+	{Code: "ka", Name: "Georgian"},
+	{Code: "gu", Name: "Gujarati"},
+	{Code: "ht", Name: "Haitian Creole"},
+	{Code: "ha", Name: "Hausa"},
+	{Code: "is", Name: "Icelandic"},
+	{Code: "ig", Name: "Igbo"},
+	{Code: "ga", Name: "Irish"},
+	{Code: "jv", Name: "Javanese"},
+	{Code: "kn", Name: "Kannada"},
+	{Code: "kk", Name: "Kazakh"},
+	{Code: "km", Name: "Khmer"},
+	{Code: "rw", Name: "Kinyarwanda"},
+	{Code: "ku", Name: "Kurdish"},
+	{Code: "ky", Name: "Kyrgyz"},
+	{Code: "lo", Name: "Lao"},
+	{Code: "la", Name: "Latin"},
+	{Code: "lv", Name: "Latvian"},
+	{Code: "lt", Name: "Lithuanian"},
+	{Code: "lb", Name: "Luxembourgish"},
+	{Code: "mk", Name: "Macedonian"},
+	{Code: "mg", Name: "Malagasy"},
+	{Code: "ml", Name: "Malayalam"},
+	{Code: "mt", Name: "Maltese"},
+	{Code: "mi", Name: "Maori"},
+	{Code: "mr", Name: "Marathi"},
+	{Code: "mn", Name: "Mongolian"},
+	{Code: "ne", Name: "Nepali"},
+	{Code: "ps", Name: "Pashto"},
+	{Code: "fa", Name: "Persian"},
+	{Code: "pa", Name: "Punjabi"},
+	{Code: "sm", Name: "Samoan"},
+	{Code: "gd", Name: "Scottish Gaelic"},
+	{Code: "sr", Name: "Serbian"},
+	{Code: "sn", Name: "Shona"},
+	{Code: "sd", Name: "Sindhi"},
+	{Code: "si", Name: "Sinhala"},
+	{Code: "sk", Name: "Slovak"},
+	{Code: "sl", Name: "Slovenian"},
+	{Code: "so", Name: "Somali"},
+	{Code: "sw", Name: "Swahili"},
+	{Code: "tg", Name: "Tajik"},
+	{Code: "ta", Name: "Tamil"},
+	{Code: "tt", Name: "Tatar"},
+	{Code: "te", Name: "Telugu"},
+	{Code: "tk", Name: "Turkmen"},
+	{Code: "ur", Name: "Urdu"},
+	{Code: "ug", Name: "Uyghur"},
+	{Code: "uz", Name: "Uzbek"},
+	{Code: "cy", Name: "Welsh"},
+	{Code: "xh", Name: "Xhosa"},
+	{Code: "yi", Name: "Yiddish"},
+	{Code: "yo", Name: "Yoruba"},
+	{Code: "zu", Name: "Zulu"},
+}
+
+// regionalLanguages are common region-qualified variants MyMemory accepts,
+// listed by languages --full. zh-TW is in moreLanguages.
+var regionalLanguages = []Language{
+	{Code: "en-US", Name: "English (United States)"},
+	{Code: "en-GB", Name: "English (United Kingdom)"},
+	{Code: "en-AU", Name: "English (Australia)"},
+	{Code: "en-CA", Name: "English (Canada)"},
+	{Code: "es-ES", Name: "Spanish (Spain)"},
+	{Code: "es-MX", Name: "Spanish (Mexico)"},
+	{Code: "es-AR", Name: "Spanish (Argentina)"},
+	{Code: "fr-FR", Name: "French (France)"},
+	{Code: "fr-CA", Name: "French (Canada)"},
+	{Code: "de-DE", Name: "German (Germany)"},
+	{Code: "de-AT", Name: "German (Austria)"},
+	{Code: "de-CH", Name: "German (Switzerland)"},
+	{Code: "it-IT", Name: "Italian (Italy)"},
+	{Code: "nl-NL", Name: "Dutch (Netherlands)"},
+	{Code: "nl-BE", Name: "Dutch (Belgium)"},
+	{Code: "pt-BR", Name: "Portuguese (Brazil)"},
+	{Code: "pt-PT", Name: "Portuguese (Portugal)"},
+	{Code: "zh-CN", Name: "Chinese (China)"},
+	{Code: "zh-HK", Name: "Chinese (Hong Kong)"},
+}
+
+// validLangCode checks that code is a language tag of the form ll, lll,
+// ll-RR or ll-NNN and returns it in canonical case (pt-br and pt_BR become
+// pt-BR). "auto" is accepted for source detection. Well-formed codes are
+// passed through even when not listed, since MyMemory supports more than
+// the languages command shows.
+func validLangCode(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if strings.EqualFold(code, "auto") {
+		return "auto", nil
+	}
+
+	lang, region, hasRegion := strings.Cut(strings.ReplaceAll(code, "_", "-"), "-")
+	ok := isLetters(lang) && (len(lang) == 2 || len(lang) == 3)
+	if hasRegion {
+		switch {
+		case len(region) == 2 && isLetters(region):
+			region = strings.ToUpper(region)
+		case len(region) == 3 && strings.Trim(region, "0123456789") == "":
+		default:
+			ok = false
+		}
+	}
+	if !ok {
+		return "", output.PrintError("invalid_input", "Unsupported language code: "+code,
+			map[string]string{"expected": "a code like pt or pt-BR; see the languages command"})
+	}
+
+	if hasRegion {
+		return strings.ToLower(lang) + "-" + region, nil
+	}
+	return strings.ToLower(lang), nil
+}
+
+// isLetters reports whether s is non-empty and all ASCII letters
+func isLetters(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
 func newLanguagesCmd() *cobra.Command {
+	var search string
+	var full bool
+
 	cmd := &cobra.Command{
 		Use:   "languages",
 		Short: "List common supported languages",
+		Long: `List common supported languages, or every known one with --full, including
+region-qualified variants such as pt-BR and en-GB. Use --search to filter by
+a case-insensitive substring of the name or code.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// MyMemory supports many languages, here are the most common ones
-			languages := []Language{
-				{Code: "en", Name: "English"},
-				{Code: "es", Name: "Spanish"},
-				{Code: "fr", Name: "French"},
-				{Code: "de", Name: "German"},
-				{Code: "it", Name: "Italian"},
-				{Code: "pt", Name: "Portuguese"},
-				{Code: "ru", Name: "Russian"},
-				{Code: "zh", Name: "Chinese (Simplified)"},
-				{Code: "ja", Name: "Japanese"},
-				{Code: "ko", Name: "Korean"},
-				{Code: "ar", Name: "Arabic"},
-				{Code: "hi", Name: "Hindi"},
-				{Code: "nl", Name: "Dutch"},
-				{Code: "pl", Name: "Polish"},
-				{Code: "tr", Name: "Turkish"},
-				{Code: "vi", Name: "Vietnamese"},
-				{Code: "th", Name: "Thai"},
-				{Code: "id", Name: "Indonesian"},
-				{Code: "ms", Name: "Malay"},
-				{Code: "sv", Name: "Swedish"},
-				{Code: "da", Name: "Danish"},
-				{Code: "no", Name: "Norwegian"},
-				{Code: "fi", Name: "Finnish"},
-				{Code: "el", Name: "Greek"},
-				{Code: "he", Name: "Hebrew"},
-				{Code: "cs", Name: "Czech"},
-				{Code: "ro", Name: "Romanian"},
-				{Code: "hu", Name: "Hungarian"},
-				{Code: "uk", Name: "Ukrainian"},
-				{Code: "bn", Name: "Bengali"},
+			languages := commonLanguages
+			if full {
+				languages = append(append(append([]Language{}, commonLanguages...), moreLanguages...), regionalLanguages...)
+			}
+			if search != "" {
+				languages = searchLanguages(languages, search)
 			}
-
 			return output.Print(languages)
 		},
 	}
 
+	cmd.Flags().StringVarP(&search, "search", "s", "", "Only languages whose name or code contains this text")
+	cmd.Flags().BoolVar(&full, "full", false, "Include every known language, not just the common ones")
+
 	return cmd
 }
 
+// searchLanguages returns the languages whose name or code contains query,
+// ignoring case
+func searchLanguages(languages []Language, query string) []Language {
+	query = strings.ToLower(strings.TrimSpace(query))
+	matches := []Language{}
+	for _, l := range languages {
+		if strings.Contains(strings.ToLower(l.Name), query) || strings.Contains(strings.ToLower(l.Code), query) {
+			matches = append(matches, l)
+		}
+	}
+	return matches
+}
+
 // bestTranslation picks the most reliable translation from the matches array.
 // MyMemory's top responseData result can be wrong due to bad community data.
 // This function finds the highest match score, then among all translations
 // with that score, returns the one that appears most frequently.
-func bestTranslation(matches []struct {
-	Translation string  `json:"translation"`
-	Match       float64 `json:"match"`
-	Quality     any     `json:"quality"`
-}) (string, float64) {
+func bestTranslation(matches []apiMatch) (string, float64) {
 	if len(matches) == 0 {
 		return "", 0
 	}
@@ -226,7 +1104,7 @@ func doRequest(reqURL string) (*http.Response, error) {
 
 	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, http.NoBody)
 	if err != nil {
-		return nil, output.PrintError("fetch_failed", err.Error(), nil)
+		return nil, &translateError{Code: "fetch_failed", Message: err.Error()}
 	}
 
 	req.Header.Set("User-Agent", "Pocket-CLI/1.0")
@@ -234,17 +1112,17 @@ func doRequest(reqURL string) (*http.Response, error) {
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
-		return nil, output.PrintError("fetch_failed", err.Error(), nil)
+		return nil, &translateError{Code: "fetch_failed", Message: err.Error()}
 	}
 
 	if resp.StatusCode == 429 {
 		resp.Body.Close()
-		return nil, output.PrintError("rate_limited", "Rate limit exceeded, try again later", nil)
+		return nil, &translateError{Code: "rate_limited", Message: "Rate limit exceeded, try again later"}
 	}
 
 	if resp.StatusCode >= 400 {
 		resp.Body.Close()
-		return nil, output.PrintError("fetch_failed", fmt.Sprintf("HTTP %d", resp.StatusCode), nil)
+		return nil, &translateError{Code: "fetch_failed", Message: fmt.Sprintf("HTTP %d", resp.StatusCode)}
 	}
 
 	return resp, nil
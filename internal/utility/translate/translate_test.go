@@ -2,8 +2,11 @@ package translate
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 )
 
@@ -22,7 +25,7 @@ func TestNewCmd(t *testing.T) {
 	for _, s := range cmd.Commands() {
 		subs[s.Use] = true
 	}
-	for _, name := range []string{"text [text]", "languages"} {
+	for _, name := range []string{"text [text]", "languages", "batch", "detect [text]"} {
 		if !subs[name] {
 			t.Errorf("missing subcommand %q", name)
 		}
@@ -109,6 +112,73 @@ func TestLanguagesCmd(t *testing.T) {
 	}
 }
 
+func TestSearchLanguages(t *testing.T) {
+	full := append(append([]Language{}, commonLanguages...), moreLanguages...)
+
+	got := searchLanguages(full, "CHIN")
+	if len(got) != 2 || got[0].Code != "zh" || got[1].Code != "zh-TW" {
+		t.Errorf("search chin = %+v", got)
+	}
+	if got := searchLanguages(commonLanguages, "swa"); len(got) != 0 {
+		t.Errorf("common search swa = %+v, want none", got)
+	}
+	if got := searchLanguages(full, "swa"); len(got) != 1 || got[0].Name != "Swahili" {
+		t.Errorf("full search swa = %+v, want Swahili", got)
+	}
+}
+
+func TestValidLangCode(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"en", "en", true},
+		{"FIL", "fil", true},
+		{"pt-BR", "pt-BR", true},
+		{"pt-br", "pt-BR", true},
+		{"en_GB", "en-GB", true},
+		{"es-419", "es-419", true},
+		{"Auto", "auto", true},
+		{"english", "", false},
+		{"pt-Brazil", "", false},
+		{"p", "", false},
+		{"pt-", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, err := validLangCode(tt.in)
+		if (err == nil) != tt.ok || got != tt.want {
+			t.Errorf("validLangCode(%q) = %q, %v; want %q, ok=%v", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestTextCmdRegionCode(t *testing.T) {
+	var langpair string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		langpair = r.URL.Query().Get("langpair")
+		json.NewEncoder(w).Encode(map[string]any{
+			"responseStatus": 200,
+			"responseData":   map[string]any{"translatedText": "Olá", "match": 1.0},
+		})
+	}))
+	defer srv.Close()
+
+	oldURL := baseURL
+	baseURL = srv.URL
+	defer func() { baseURL = oldURL }()
+
+	cmd := newTextCmd()
+	cmd.SetArgs([]string{"Hello", "--from", "en", "--to", "pt_br"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("text command failed: %v", err)
+	}
+	if langpair != "en|pt-BR" {
+		t.Errorf("langpair = %q, want en|pt-BR", langpair)
+	}
+}
+
 func TestRateLimitHandling(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusTooManyRequests)
@@ -163,3 +233,409 @@ func TestParseError(t *testing.T) {
 		t.Error("expected parse error, got nil")
 	}
 }
+
+func TestTranslateBatch(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		q := r.URL.Query().Get("q")
+		if q == "fail" {
+			json.NewEncoder(w).Encode(map[string]any{
+				"responseStatus":  403,
+				"responseDetails": "bad input",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"responseStatus": 200,
+			"responseData": map[string]any{
+				"translatedText": "[" + q + "]",
+				"match":          1.0,
+			},
+		})
+	}))
+	defer srv.Close()
+
+	oldURL := baseURL
+	baseURL = srv.URL
+	defer func() { baseURL = oldURL }()
+
+	texts := []string{"one", "two", "fail", "one"}
+	translations, errs := translateBatch(texts, "en", "fr", 2)
+
+	if len(translations) != len(texts) {
+		t.Fatalf("expected %d translations, got %d", len(texts), len(translations))
+	}
+	for i, want := range []string{"[one]", "[two]", "", "[one]"} {
+		if translations[i].TranslatedText != want {
+			t.Errorf("translation %d = %q, want %q", i, translations[i].TranslatedText, want)
+		}
+	}
+	if len(errs) != 1 || errs[0].Index != 2 || errs[0].Code != "api_error" {
+		t.Errorf("unexpected errors: %+v", errs)
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("expected duplicate strings to be translated once (3 calls), got %d", n)
+	}
+}
+
+func TestTranslateAll(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pair := r.URL.Query().Get("langpair")
+		if pair == "en|ja" {
+			json.NewEncoder(w).Encode(map[string]any{
+				"responseStatus":  403,
+				"responseDetails": "bad pair",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"responseStatus": 200,
+			"responseData": map[string]any{
+				"translatedText": pair + ":" + r.URL.Query().Get("q"),
+				"match":          1.0,
+			},
+		})
+	}))
+	defer srv.Close()
+
+	oldURL := baseURL
+	baseURL = srv.URL
+	defer func() { baseURL = oldURL }()
+
+	translations, errs := translateAll("Save Acme", "en", []string{"Acme"}, 3)
+
+	if want := len(commonLanguages) - 2; len(translations) != want {
+		t.Fatalf("expected %d translations, got %d", want, len(translations))
+	}
+	for _, tr := range translations {
+		if tr.TargetLang == "en" {
+			t.Error("source language should not be a target")
+		}
+		if tr.SourceText != "Save Acme" || !strings.HasSuffix(tr.TranslatedText, "Save Acme") {
+			t.Errorf("unexpected translation: %+v", tr)
+		}
+	}
+	if translations[0].TargetLang != "es" {
+		t.Errorf("first target = %q, want es", translations[0].TargetLang)
+	}
+	if len(errs) != 1 || errs[0].TargetLang != "ja" || errs[0].Code != "api_error" {
+		t.Errorf("unexpected errors: %+v", errs)
+	}
+}
+
+func TestCheckLength(t *testing.T) {
+	cjk := strings.Repeat("漢", 200) // 600 bytes, 200 characters
+
+	tests := []struct {
+		text        string
+		wantChars   int
+		wantLongest int
+	}{
+		{"Hello", 5, 5},
+		{cjk, 200, 600},
+		{"short\n  " + strings.Repeat("a", 300) + "  \nend", 314, 300},
+	}
+	for _, tt := range tests {
+		got := checkLength(tt.text)
+		if got.Characters != tt.wantChars || got.LongestSegmentBytes != tt.wantLongest || got.LimitBytes != maxQueryBytes {
+			t.Errorf("checkLength(%.10q) = %+v, want %d chars, longest %d", tt.text, got, tt.wantChars, tt.wantLongest)
+		}
+	}
+}
+
+func TestTextCmdStrictLength(t *testing.T) {
+	cmd := newTextCmd()
+	cmd.SetArgs([]string{strings.Repeat("漢", 200), "--strict"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil {
+		t.Error("expected --strict to reject an over-length request")
+	}
+}
+
+func TestTranslateWithOverride(t *testing.T) {
+	tests := []struct {
+		name        string
+		autoMatch   float64
+		autoStatus  int
+		wantAttempt string
+		wantText    string
+	}{
+		{"good auto match", 0.9, 200, "auto", "auto-result"},
+		{"poor auto match", 0.2, 200, "override", "en-result"},
+		{"auto fails", 0, 403, "override", "en-result"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("langpair") == "Autodetect|es" {
+					json.NewEncoder(w).Encode(map[string]any{
+						"responseStatus":  tt.autoStatus,
+						"responseDetails": "detection failed",
+						"responseData":    map[string]any{"translatedText": "auto-result", "match": tt.autoMatch},
+					})
+					return
+				}
+				json.NewEncoder(w).Encode(map[string]any{
+					"responseStatus": 200,
+					"responseData":   map[string]any{"translatedText": "en-result", "match": 0.7},
+				})
+			}))
+			defer srv.Close()
+
+			oldURL := baseURL
+			baseURL = srv.URL
+			defer func() { baseURL = oldURL }()
+
+			tr, err := translateWithOverride("hi", "en", "es", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tr.Attempt != tt.wantAttempt || tr.TranslatedText != tt.wantText {
+				t.Errorf("got attempt=%q text=%q, want %q %q", tr.Attempt, tr.TranslatedText, tt.wantAttempt, tt.wantText)
+			}
+		})
+	}
+}
+
+func TestTranslateWithRetryRateLimited(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"responseStatus": 200,
+			"responseData":   map[string]any{"translatedText": "Hola"},
+		})
+	}))
+	defer srv.Close()
+
+	oldURL, oldBackoff := baseURL, rateLimitBackoff
+	baseURL, rateLimitBackoff = srv.URL, 0
+	defer func() { baseURL, rateLimitBackoff = oldURL, oldBackoff }()
+
+	tr, err := translateWithRetry("Hello", "en", "es")
+	if err != nil {
+		t.Fatalf("expected retry to succeed, got %v", err)
+	}
+	if tr.TranslatedText != "Hola" || calls != 2 {
+		t.Errorf("got %q after %d calls", tr.TranslatedText, calls)
+	}
+}
+
+func TestTranslateTextPreservesLines(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		q := r.URL.Query().Get("q")
+		if strings.ContainsAny(q, "\n\t") || strings.TrimSpace(q) != q {
+			t.Errorf("segment sent with layout whitespace: %q", q)
+		}
+		data := map[string]any{
+			"responseStatus": 200,
+			"responseData": map[string]any{
+				"translatedText": strings.ToUpper(q),
+				"match":          0.9,
+			},
+		}
+		json.NewEncoder(w).Encode(data)
+	}))
+	defer srv.Close()
+
+	oldURL := baseURL
+	baseURL = srv.URL
+	defer func() { baseURL = oldURL }()
+
+	input := "Hello,\n\n  - first item\n\tsecond item  \n"
+	tr, err := translateText(input, "en", "es")
+	if err != nil {
+		t.Fatalf("translateText failed: %v", err)
+	}
+
+	want := "HELLO,\n\n  - FIRST ITEM\n\tSECOND ITEM  \n"
+	if tr.TranslatedText != want {
+		t.Errorf("TranslatedText = %q, want %q", tr.TranslatedText, want)
+	}
+	if tr.SourceText != input {
+		t.Errorf("SourceText = %q, want original input", tr.SourceText)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("expected 3 requests for 3 non-blank lines, got %d", n)
+	}
+}
+
+func TestTranslateKeepingProtectsTerms(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		if strings.Contains(q, "Acme") || strings.Contains(q, "Widget Pro") {
+			t.Errorf("protected term sent to the API: %q", q)
+		}
+		// Simulate a translator that rewrites ordinary words only
+		out := strings.NewReplacer("Buy", "Compra", "from", "de").Replace(q)
+		data := map[string]any{
+			"responseStatus": 200,
+			"responseData": map[string]any{
+				"translatedText": out,
+				"match":          1.0,
+			},
+		}
+		json.NewEncoder(w).Encode(data)
+	}))
+	defer srv.Close()
+
+	oldURL := baseURL
+	baseURL = srv.URL
+	defer func() { baseURL = oldURL }()
+
+	input := "Buy Widget Pro from Acme"
+	tr, err := translateKeeping(input, "en", "es", []string{"Acme", "Widget", "Widget Pro"})
+	if err != nil {
+		t.Fatalf("translateKeeping failed: %v", err)
+	}
+	if want := "Compra Widget Pro de Acme"; tr.TranslatedText != want {
+		t.Errorf("TranslatedText = %q, want %q", tr.TranslatedText, want)
+	}
+	if tr.SourceText != input {
+		t.Errorf("SourceText = %q, want %q", tr.SourceText, input)
+	}
+}
+
+func TestRomanizeText(t *testing.T) {
+	tests := []struct {
+		in, lang, want string
+	}{
+		{"Привет", "ru", "Privet"},
+		{"Ελληνικά", "el", "Ellenika"},
+		{"Hola mundo", "es", ""},
+		// Kanji would be read as Mandarin ("Ri Ben Yu"), so ja is left out
+		{"日本語を話します", "ja", ""},
+		{"日本語を話します", "ja-JP", ""},
+	}
+	for _, tt := range tests {
+		if got := romanizeText(tt.in, tt.lang); got != tt.want {
+			t.Errorf("romanizeText(%q, %q) = %q, want %q", tt.in, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		detected any
+		wantLang string
+		wantConf string
+	}{
+		// The match score is not a detection confidence, so none is reported
+		{"bare code", "it-IT", "it", "none"},
+		{"object", map[string]any{"language": "fr", "confidence": 0.42}, "fr", "0.42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("langpair"); got != "Autodetect|en" {
+					t.Errorf("langpair = %q", got)
+				}
+				json.NewEncoder(w).Encode(map[string]any{
+					"responseStatus": 200,
+					"responseData": map[string]any{
+						"translatedText":   "hello",
+						"match":            0.8,
+						"detectedLanguage": tt.detected,
+					},
+				})
+			}))
+			defer srv.Close()
+
+			oldURL := baseURL
+			baseURL = srv.URL
+			defer func() { baseURL = oldURL }()
+
+			d, err := detectLanguage("ciao")
+			if err != nil {
+				t.Fatalf("detectLanguage failed: %v", err)
+			}
+			conf := "none"
+			if d.Confidence != nil {
+				conf = fmt.Sprintf("%.2f", *d.Confidence)
+			}
+			if d.Language != tt.wantLang || conf != tt.wantConf {
+				t.Errorf("got %s/%s, want %s/%s", d.Language, conf, tt.wantLang, tt.wantConf)
+			}
+		})
+	}
+}
+
+func TestLowConfidence(t *testing.T) {
+	low, high := 0.3, 0.9
+	tests := []struct {
+		name string
+		conf *float64
+		want bool
+	}{
+		{"below", &low, true},
+		{"above", &high, false},
+		{"no score", nil, true},
+	}
+	for _, tt := range tests {
+		got := lowConfidence(Detection{Language: "fr", Confidence: tt.conf}, 0.5) != ""
+		if got != tt.want {
+			t.Errorf("%s: lowConfidence = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTextCmdMinConfidence(t *testing.T) {
+	var translated int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("langpair") == "Autodetect|en" {
+			json.NewEncoder(w).Encode(map[string]any{
+				"responseStatus": 200,
+				"responseData": map[string]any{
+					"translatedText":   "hi",
+					"match":            1,
+					"detectedLanguage": map[string]any{"language": "it", "confidence": 0.2},
+				},
+			})
+			return
+		}
+		atomic.AddInt32(&translated, 1)
+		json.NewEncoder(w).Encode(map[string]any{
+			"responseStatus": 200,
+			"responseData":   map[string]any{"translatedText": "hola", "match": 1},
+		})
+	}))
+	defer srv.Close()
+
+	oldURL := baseURL
+	baseURL = srv.URL
+	defer func() { baseURL = oldURL }()
+
+	tests := []struct {
+		name           string
+		args           []string
+		wantErr        bool
+		wantTranslated int32
+	}{
+		{"warns", []string{"ciao", "--from", "auto", "--min-confidence", "0.5"}, false, 1},
+		{"strict fails", []string{"ciao", "--from", "auto", "--min-confidence", "0.5", "--strict"}, true, 0},
+		{"needs auto", []string{"ciao", "--from", "it", "--min-confidence", "0.5"}, true, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&translated, 0)
+			cmd := newTextCmd()
+			cmd.SetArgs(tt.args)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			if err := cmd.Execute(); (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n := atomic.LoadInt32(&translated); n != tt.wantTranslated {
+				t.Errorf("translate requests = %d, want %d", n, tt.wantTranslated)
+			}
+		})
+	}
+}
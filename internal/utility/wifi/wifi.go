@@ -2,13 +2,22 @@ package wifi
 
 import (
 	"bufio"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"reflect"
 	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -17,29 +26,53 @@ import (
 
 // Network represents a WiFi network
 type Network struct {
-	SSID     string `json:"ssid"`
-	BSSID    string `json:"bssid,omitempty"`
-	RSSI     int    `json:"rssi,omitempty"`
-	Channel  int    `json:"channel,omitempty"`
-	Security string `json:"security,omitempty"`
+	SSID         string `json:"ssid"`
+	BSSID        string `json:"bssid,omitempty"`
+	RSSI         int    `json:"rssi,omitempty"`
+	Channel      int    `json:"channel,omitempty"`
+	Band         string `json:"band,omitempty"`
+	ChannelWidth int    `json:"channel_width_mhz,omitempty"`
+	Security     string `json:"security,omitempty"`
+
+	// Set by scan --compare-to-current
+	Connected     bool `json:"connected,omitempty"`
+	RSSIDelta     *int `json:"rssi_delta,omitempty"`
+	RoamCandidate bool `json:"roam_candidate,omitempty"`
 }
 
 // ScanResult holds WiFi scan results
 type ScanResult struct {
-	Networks []Network `json:"networks"`
-	Count    int       `json:"count"`
+	Networks       []Network       `json:"networks"`
+	Count          int             `json:"count"`
+	Current        *ConnectionInfo `json:"current,omitempty"`
+	RoamSuggestion *RoamSuggestion `json:"roam_suggestion,omitempty"`
+}
+
+// RoamSuggestion is a same-SSID access point meaningfully stronger than the
+// connected one
+type RoamSuggestion struct {
+	SSID        string `json:"ssid"`
+	BSSID       string `json:"bssid,omitempty"`
+	Channel     int    `json:"channel,omitempty"`
+	Band        string `json:"band,omitempty"`
+	RSSI        int    `json:"rssi"`
+	CurrentRSSI int    `json:"current_rssi"`
+	GainDB      int    `json:"gain_db"`
+	Reason      string `json:"reason"`
 }
 
 // ConnectionInfo holds current WiFi connection details
 type ConnectionInfo struct {
-	SSID      string `json:"ssid"`
-	BSSID     string `json:"bssid,omitempty"`
-	RSSI      int    `json:"rssi,omitempty"`
-	Noise     int    `json:"noise,omitempty"`
-	Channel   int    `json:"channel,omitempty"`
-	TxRate    string `json:"tx_rate,omitempty"`
-	Security  string `json:"security,omitempty"`
-	Connected bool   `json:"connected"`
+	Interface    string `json:"interface,omitempty"`
+	SSID         string `json:"ssid"`
+	BSSID        string `json:"bssid,omitempty"`
+	RSSI         int    `json:"rssi,omitempty"`
+	Noise        int    `json:"noise,omitempty"`
+	Channel      int    `json:"channel,omitempty"`
+	ChannelWidth int    `json:"channel_width_mhz,omitempty"`
+	TxRate       string `json:"tx_rate,omitempty"`
+	Security     string `json:"security,omitempty"`
+	Connected    bool   `json:"connected"`
 }
 
 func NewCmd() *cobra.Command {
@@ -51,49 +84,1093 @@ func NewCmd() *cobra.Command {
 
 	cmd.AddCommand(newScanCmd())
 	cmd.AddCommand(newCurrentCmd())
+	cmd.AddCommand(newSavedCmd())
+	cmd.AddCommand(newRecommendCmd())
+	cmd.AddCommand(newPortalCmd())
+	cmd.AddCommand(newHistoryCmd())
+	cmd.AddCommand(newSchemaCmd())
+	cmd.AddCommand(newPowerCmd("enable", true))
+	cmd.AddCommand(newPowerCmd("disable", false))
+
+	cmd.PersistentFlags().BoolVar(&passiveCache, "passive-cache", false, "Reuse recent raw scan and connection output, cached per interface, instead of rerunning the scanner")
+	cmd.PersistentFlags().DurationVar(&cacheTTL, "cache-ttl", 30*time.Second, "Maximum age of cached scan output used with --passive-cache")
+	cmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Force a fresh scan even when --passive-cache is set")
 
 	return cmd
 }
 
+var (
+	passiveCache bool
+	cacheTTL     = 30 * time.Second
+	noCache      bool
+)
+
+// cacheEntry is raw scanner output persisted between invocations
+type cacheEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Data      []byte    `json:"data"`
+}
+
+// cacheFile returns the on-disk location of the cache for a given key
+var cacheFile = func(key string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "pocket", "wifi", key+".json")
+}
+
+// readCache returns cached output for key if it is younger than ttl
+func readCache(key string, ttl time.Duration) ([]byte, bool) {
+	data, err := os.ReadFile(cacheFile(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if time.Since(entry.Timestamp) > ttl {
+		return nil, false
+	}
+	return entry.Data, true
+}
+
+// writeCache stores output for key; failures are ignored since the cache is
+// purely an optimization.
+func writeCache(key string, out []byte) {
+	path := cacheFile(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	data, err := json.Marshal(cacheEntry{Timestamp: time.Now(), Data: out})
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+// cacheKey names the cache entry for a command's output about one interface.
+// Commands that report every interface at once use "all".
+func cacheKey(command, iface string) string {
+	if iface == "" {
+		iface = "all"
+	}
+	iface = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, iface)
+	return command + "_" + iface
+}
+
+// cacheEnabled reports whether --passive-cache output may be reused
+func cacheEnabled() bool {
+	return passiveCache && !noCache
+}
+
+// runCached runs a scanner command, reusing recent output for the same key
+// when --passive-cache is set
+func runCached(key, name string, args ...string) ([]byte, error) {
+	return runScanner(cacheEnabled(), key, name, args...)
+}
+
+// runScanner runs a scanner command, reusing recent output for key when
+// useCache is set. Fresh output is always written back so later cached
+// calls can benefit from it.
+func runScanner(useCache bool, key, name string, args ...string) ([]byte, error) {
+	if useCache {
+		if out, ok := readCache(key, cacheTTL); ok {
+			return out, nil
+		}
+	}
+
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return out, err
+	}
+	writeCache(key, out)
+	return out, nil
+}
+
 func newScanCmd() *cobra.Command {
-	return &cobra.Command{
+	var csvOut bool
+	var samples int
+	var interval time.Duration
+	var knownOnly bool
+	var iface string
+	var onlyOpen bool
+	var compareCurrent bool
+
+	cmd := &cobra.Command{
 		Use:   "scan",
 		Short: "Scan nearby WiFi networks with signal strength",
+		Long: `Scan nearby WiFi networks with signal strength.
+
+With --samples, several fresh scans are aggregated into per-network
+average/min/max RSSI; --known-only, --only-open and --csv apply to the
+aggregated result.
+
+With --compare-to-current, each network is annotated with whether it is the
+connected access point and, for other access points on the same SSID, its
+signal difference from the current one. A roam_suggestion is included when a
+same-SSID access point is at least 8 dB stronger.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return scanNetworks()
+			var saved []string
+			if knownOnly {
+				var err error
+				if saved, err = savedNetworks(iface); err != nil {
+					return err
+				}
+			}
+			filter := func(networks []Network) []Network {
+				if knownOnly {
+					networks = filterKnown(networks, saved)
+				}
+				if onlyOpen {
+					networks = filterOpen(networks)
+				}
+				return networks
+			}
+
+			if samples > 1 {
+				stats, err := sampleScans(samples, interval, filter)
+				if err != nil {
+					return err
+				}
+				if csvOut {
+					return writeStatsCSV(os.Stdout, stats)
+				}
+				return output.Print(map[string]any{
+					"samples":  samples,
+					"interval": interval.String(),
+					"count":    len(stats),
+					"networks": stats,
+				})
+			}
+
+			networks, err := scanNetworks(cacheEnabled())
+			if err != nil {
+				return err
+			}
+			networks = filter(networks)
+
+			if csvOut {
+				return writeCSV(os.Stdout, networks)
+			}
+
+			result := ScanResult{
+				Networks: networks,
+				Count:    len(networks),
+			}
+			if compareCurrent {
+				info, err := currentInfo()
+				if err != nil {
+					return err
+				}
+				result.Current = &info
+				if info.Connected {
+					result.RoamSuggestion = compareToCurrent(info, networks)
+				}
+			}
+
+			return output.Print(result)
 		},
 	}
+
+	cmd.Flags().BoolVar(&csvOut, "csv", false, "Output as CSV rows (ssid,bssid,rssi,channel,band,security; with --samples: ssid,bssid,avg_rssi,min_rssi,max_rssi,seen_count)")
+	cmd.Flags().BoolVar(&knownOnly, "known-only", false, "Only show networks saved on this machine")
+	cmd.Flags().StringVarP(&iface, "interface", "i", "en0", "WiFi interface whose saved networks --known-only uses (macOS only)")
+	cmd.Flags().BoolVar(&onlyOpen, "only-open", false, "Only show unsecured networks (see also: wifi portal)")
+	cmd.Flags().BoolVar(&compareCurrent, "compare-to-current", false, "Mark the connected AP and suggest a stronger same-SSID AP to roam to")
+	cmd.Flags().IntVar(&samples, "samples", 1, "Number of scans to aggregate into average/min/max RSSI")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Delay between scans when --samples > 1")
+	cmd.MarkFlagsMutuallyExclusive("compare-to-current", "csv")
+	cmd.MarkFlagsMutuallyExclusive("compare-to-current", "samples")
+
+	return cmd
+}
+
+// NetworkStats aggregates a network's signal strength over several scans
+type NetworkStats struct {
+	SSID      string  `json:"ssid"`
+	BSSID     string  `json:"bssid,omitempty"`
+	AvgRSSI   float64 `json:"avg_rssi"`
+	MinRSSI   int     `json:"min_rssi"`
+	MaxRSSI   int     `json:"max_rssi"`
+	SeenCount int     `json:"seen_count"`
+}
+
+// sampleScans runs several scans, bypassing the passive cache since cached
+// output would make every sample identical, and aggregates the filtered
+// results into per-network RSSI statistics
+func sampleScans(samples int, interval time.Duration, filter func([]Network) []Network) ([]NetworkStats, error) {
+	scans := make([][]Network, 0, samples)
+	for i := 0; i < samples; i++ {
+		if i > 0 {
+			time.Sleep(interval)
+		}
+		networks, err := scanNetworks(false)
+		if err != nil {
+			return nil, err
+		}
+		scans = append(scans, filter(networks))
+	}
+	return aggregateScans(scans), nil
+}
+
+// writeStatsCSV writes aggregated scan statistics as CSV rows
+func writeStatsCSV(w io.Writer, stats []NetworkStats) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"ssid", "bssid", "avg_rssi", "min_rssi", "max_rssi", "seen_count"})
+	for _, st := range stats {
+		_ = cw.Write([]string{
+			st.SSID,
+			st.BSSID,
+			strconv.FormatFloat(st.AvgRSSI, 'f', -1, 64),
+			strconv.Itoa(st.MinRSSI),
+			strconv.Itoa(st.MaxRSSI),
+			strconv.Itoa(st.SeenCount),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// aggregateScans combines scans into per-network RSSI statistics keyed by
+// BSSID, or SSID when no BSSID is reported. Results are sorted by average
+// RSSI, strongest first.
+func aggregateScans(scans [][]Network) []NetworkStats {
+	byKey := make(map[string]*NetworkStats)
+	sums := make(map[string]int)
+	var order []string
+
+	for _, networks := range scans {
+		for _, n := range networks {
+			key := n.BSSID
+			if key == "" {
+				key = n.SSID
+			}
+			st, ok := byKey[key]
+			if !ok {
+				st = &NetworkStats{SSID: n.SSID, BSSID: n.BSSID, MinRSSI: n.RSSI, MaxRSSI: n.RSSI}
+				byKey[key] = st
+				order = append(order, key)
+			}
+			st.SeenCount++
+			sums[key] += n.RSSI
+			if n.RSSI < st.MinRSSI {
+				st.MinRSSI = n.RSSI
+			}
+			if n.RSSI > st.MaxRSSI {
+				st.MaxRSSI = n.RSSI
+			}
+		}
+	}
+
+	stats := make([]NetworkStats, 0, len(order))
+	for _, key := range order {
+		st := byKey[key]
+		st.AvgRSSI = float64(sums[key]) / float64(st.SeenCount)
+		stats = append(stats, *st)
+	}
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].AvgRSSI > stats[j].AvgRSSI
+	})
+	return stats
 }
 
 func newCurrentCmd() *cobra.Command {
-	return &cobra.Command{
+	var allInterfaces bool
+	var speedtest bool
+	var speedtestURL string
+
+	cmd := &cobra.Command{
 		Use:   "current",
 		Short: "Show current WiFi connection details",
+		Long: `Show current WiFi connection details.
+
+The tx_rate is the negotiated link rate, an upper bound rather than the speed
+you actually get. --speedtest downloads a fixed-size payload and reports the
+measured throughput alongside it. It uses real bandwidth, so it is opt-in.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if speedtest {
+				return currentWithSpeedtest(speedtestURL)
+			}
+			return currentConnection(allInterfaces)
+		},
+	}
+
+	cmd.Flags().BoolVar(&allInterfaces, "all-interfaces", false, "Report every WiFi interface instead of only the primary one")
+	cmd.Flags().BoolVar(&speedtest, "speedtest", false, "Measure actual download throughput (uses bandwidth)")
+	cmd.Flags().StringVar(&speedtestURL, "speedtest-url", defaultSpeedtestURL, "URL of the fixed-size payload downloaded by --speedtest")
+	cmd.MarkFlagsMutuallyExclusive("speedtest", "all-interfaces")
+
+	return cmd
+}
+
+// defaultSpeedtestURL serves a 10 MB payload
+const defaultSpeedtestURL = "https://speed.cloudflare.com/__down?bytes=10000000"
+
+// speedtestTimeout bounds a single throughput measurement
+const speedtestTimeout = 60 * time.Second
+
+// Throughput is a measured download over the current connection
+type Throughput struct {
+	URL     string  `json:"url"`
+	Bytes   int64   `json:"bytes"`
+	Seconds float64 `json:"seconds"`
+	Mbps    float64 `json:"mbps"`
+}
+
+// SpeedtestReport pairs the negotiated link rate with measured throughput
+type SpeedtestReport struct {
+	Connection   ConnectionInfo `json:"connection"`
+	LinkRateMbps float64        `json:"link_rate_mbps,omitempty"`
+	Throughput   Throughput     `json:"throughput"`
+	Note         string         `json:"note"`
+}
+
+func currentWithSpeedtest(url string) error {
+	info, err := currentInfo()
+	if err != nil {
+		return err
+	}
+	if !info.Connected {
+		return output.PrintError("not_connected", "Not connected to a WiFi network", nil)
+	}
+
+	tp, err := measureThroughput(url)
+	if err != nil {
+		return output.PrintError("speedtest_failed", err.Error(), map[string]string{"url": url})
+	}
+
+	return output.Print(SpeedtestReport{
+		Connection:   info,
+		LinkRateMbps: linkRateMbps(info.TxRate),
+		Throughput:   tp,
+		Note:         "link_rate_mbps is the negotiated rate; throughput.mbps is what the download achieved",
+	})
+}
+
+// measureThroughput downloads url in full and reports the achieved rate
+func measureThroughput(url string) (Throughput, error) {
+	client := &http.Client{Timeout: speedtestTimeout}
+
+	start := time.Now()
+	resp, err := client.Get(url)
+	if err != nil {
+		return Throughput{}, fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Throughput{}, fmt.Errorf("download failed: HTTP %d", resp.StatusCode)
+	}
+
+	n, err := io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return Throughput{}, fmt.Errorf("download interrupted after %d bytes: %w", n, err)
+	}
+	elapsed := time.Since(start).Seconds()
+	if n == 0 || elapsed <= 0 {
+		return Throughput{}, fmt.Errorf("empty payload from %s", url)
+	}
+
+	mbps := float64(n) * 8 / elapsed / 1e6
+	return Throughput{
+		URL:     url,
+		Bytes:   n,
+		Seconds: math.Round(elapsed*1000) / 1000,
+		Mbps:    math.Round(mbps*10) / 10,
+	}, nil
+}
+
+// linkRateMbps extracts the numeric rate from a tx_rate such as "866 Mbps"
+// or nmcli's "866 Mbit/s"; it returns 0 when none is present
+func linkRateMbps(rate string) float64 {
+	fields := strings.Fields(rate)
+	if len(fields) == 0 {
+		return 0
+	}
+	v, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+func newSavedCmd() *cobra.Command {
+	var iface string
+
+	cmd := &cobra.Command{
+		Use:   "saved",
+		Short: "List saved (preferred) WiFi networks",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			saved, err := savedNetworks(iface)
+			if err != nil {
+				return err
+			}
+			return output.Print(map[string]any{
+				"count":    len(saved),
+				"networks": saved,
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&iface, "interface", "i", "en0", "WiFi interface (macOS only)")
+
+	return cmd
+}
+
+// savedNetworks returns the SSIDs of networks this machine has saved
+func savedNetworks(iface string) ([]string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := exec.Command("networksetup", "-listpreferredwirelessnetworks", iface).CombinedOutput()
+		if err != nil {
+			return nil, output.PrintError("wifi_saved_error",
+				fmt.Sprintf("networksetup failed: %v: %s", err, strings.TrimSpace(string(out))), nil)
+		}
+		return parsePreferredNetworks(string(out)), nil
+	case "linux":
+		out, err := exec.Command("nmcli", "-t", "-f", "NAME,TYPE", "connection", "show").CombinedOutput()
+		if err != nil {
+			return nil, output.PrintError("wifi_saved_error",
+				fmt.Sprintf("nmcli failed: %v", err), nil)
+		}
+		return parseNmcliSavedConnections(string(out)), nil
+	default:
+		return nil, output.PrintError("platform_unsupported",
+			fmt.Sprintf("Saved networks not supported on %s", runtime.GOOS),
+			map[string]string{"supported": "macOS, Linux"})
+	}
+}
+
+// parsePreferredNetworks parses networksetup -listpreferredwirelessnetworks
+// output: a "Preferred networks on en0:" header followed by indented SSIDs
+func parsePreferredNetworks(out string) []string {
+	saved := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		if !strings.HasPrefix(line, "\t") {
+			continue
+		}
+		if ssid := strings.TrimSpace(line); ssid != "" {
+			saved = append(saved, ssid)
+		}
+	}
+	return saved
+}
+
+// parseNmcliSavedConnections returns WiFi connection names from terse
+// "nmcli -f NAME,TYPE connection show" output
+func parseNmcliSavedConnections(out string) []string {
+	saved := []string{}
+	for _, line := range strings.Split(out, "\n") {
+		i := strings.LastIndex(line, ":")
+		if i < 0 || line[i+1:] != "802-11-wireless" {
+			continue
+		}
+		saved = append(saved, strings.ReplaceAll(line[:i], "\\:", ":"))
+	}
+	return saved
+}
+
+// filterKnown keeps networks whose SSID is in saved
+func filterKnown(networks []Network, saved []string) []Network {
+	known := make(map[string]bool, len(saved))
+	for _, ssid := range saved {
+		known[ssid] = true
+	}
+	filtered := []Network{}
+	for _, n := range networks {
+		if known[n.SSID] {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
+
+// isOpen reports whether a security mode means the network is unsecured.
+// nmcli leaves the field empty or "--" for open networks.
+func isOpen(security string) bool {
+	switch strings.ToLower(strings.TrimSpace(security)) {
+	case "", "--", "open", "none":
+		return true
+	}
+	return false
+}
+
+// filterOpen keeps only unsecured networks
+func filterOpen(networks []Network) []Network {
+	filtered := []Network{}
+	for _, n := range networks {
+		if isOpen(n.Security) {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
+
+// isConnectedAP reports whether a scanned network is the access point cur is
+// associated with. The connected AP shows up in scans on the same channel;
+// BSSIDs are compared when the platform reports them.
+func isConnectedAP(cur ConnectionInfo, n Network) bool {
+	return n.SSID == cur.SSID && n.Channel == cur.Channel && (cur.BSSID == "" || n.BSSID == cur.BSSID)
+}
+
+// compareToCurrent annotates networks in place against the current connection
+// and returns the strongest same-SSID roaming candidate, if any
+func compareToCurrent(cur ConnectionInfo, networks []Network) *RoamSuggestion {
+	var best *Network
+	for i := range networks {
+		n := &networks[i]
+		if n.SSID != cur.SSID {
+			continue
+		}
+		if isConnectedAP(cur, *n) {
+			n.Connected = true
+			continue
+		}
+		if n.RSSI == 0 || cur.RSSI == 0 {
+			continue
+		}
+		delta := n.RSSI - cur.RSSI
+		n.RSSIDelta = &delta
+		if delta >= roamMarginDB {
+			n.RoamCandidate = true
+			if best == nil || n.RSSI > best.RSSI {
+				best = n
+			}
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	gain := best.RSSI - cur.RSSI
+	return &RoamSuggestion{
+		SSID:        best.SSID,
+		BSSID:       best.BSSID,
+		Channel:     best.Channel,
+		Band:        best.Band,
+		RSSI:        best.RSSI,
+		CurrentRSSI: cur.RSSI,
+		GainDB:      gain,
+		Reason: fmt.Sprintf("Roam to stronger AP: %s on channel %d is %d dB stronger",
+			best.SSID, best.Channel, gain),
+	}
+}
+
+// Signal thresholds used by recommend, in dBm and dB
+const (
+	rssiGood      = -60
+	rssiWeak      = -70
+	snrPoor       = 20
+	roamMarginDB  = 8
+	busyChannelAt = 3
+)
+
+// CoverageMetrics are the measurements behind a recommendation
+type CoverageMetrics struct {
+	SSID              string   `json:"ssid"`
+	RSSI              int      `json:"rssi,omitempty"`
+	Noise             int      `json:"noise,omitempty"`
+	SNR               *int     `json:"snr,omitempty"`
+	Channel           int      `json:"channel,omitempty"`
+	Band              string   `json:"band,omitempty"`
+	SignalQuality     string   `json:"signal_quality"`
+	CoChannelNetworks int      `json:"co_channel_networks"`
+	StrongerAP        *Network `json:"stronger_ap,omitempty"`
+	Band5GHz          *Network `json:"band_5ghz_ap,omitempty"`
+}
+
+// Recommendation is plain-language advice with its supporting metrics
+type Recommendation struct {
+	Recommendation string          `json:"recommendation"`
+	Actions        []string        `json:"actions"`
+	Metrics        CoverageMetrics `json:"metrics"`
+}
+
+func newRecommendCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "recommend",
+		Short: "Recommend how to improve the current WiFi connection",
+		Long: `Combine the current connection with a scan to judge signal strength and
+signal-to-noise ratio, look for a stronger access point or 5GHz radio on the
+same network, and count other networks sharing the channel. Returns a
+plain-language recommendation plus the metrics behind it.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := currentInfo()
+			if err != nil {
+				return err
+			}
+			if !info.Connected {
+				return output.PrintError("not_connected", "Not connected to a WiFi network", nil)
+			}
+
+			networks, err := scanNetworks(cacheEnabled())
+			if err != nil {
+				return err
+			}
+
+			return output.Print(recommend(info, networks))
+		},
+	}
+
+	return cmd
+}
+
+// recommend evaluates the current connection against nearby networks
+func recommend(cur ConnectionInfo, networks []Network) Recommendation {
+	m := CoverageMetrics{
+		SSID:    cur.SSID,
+		RSSI:    cur.RSSI,
+		Noise:   cur.Noise,
+		Channel: cur.Channel,
+		Band:    bandForChannel(cur.Channel),
+	}
+	if cur.RSSI != 0 && cur.Noise != 0 {
+		snr := cur.RSSI - cur.Noise
+		m.SNR = &snr
+	}
+
+	switch {
+	case cur.RSSI == 0:
+		m.SignalQuality = "unknown"
+	case cur.RSSI >= rssiGood:
+		m.SignalQuality = "good"
+	case cur.RSSI >= rssiWeak:
+		m.SignalQuality = "fair"
+	default:
+		m.SignalQuality = "weak"
+	}
+
+	for i := range networks {
+		n := networks[i]
+		if n.SSID == cur.SSID {
+			if isConnectedAP(cur, n) {
+				continue
+			}
+			if n.RSSI != 0 && cur.RSSI != 0 && n.RSSI >= cur.RSSI+roamMarginDB &&
+				(m.StrongerAP == nil || n.RSSI > m.StrongerAP.RSSI) {
+				m.StrongerAP = &networks[i]
+			}
+			if m.Band == "2.4GHz" && n.Band == "5GHz" && n.RSSI >= rssiWeak &&
+				(m.Band5GHz == nil || n.RSSI > m.Band5GHz.RSSI) {
+				m.Band5GHz = &networks[i]
+			}
+			continue
+		}
+		if cur.Channel != 0 && n.Channel == cur.Channel {
+			m.CoChannelNetworks++
+		}
+	}
+
+	actions := []string{}
+	if m.StrongerAP != nil {
+		actions = append(actions, fmt.Sprintf("Roam to stronger AP: %s on channel %d is %d dB stronger",
+			m.SSID, m.StrongerAP.Channel, m.StrongerAP.RSSI-cur.RSSI))
+	}
+	if m.Band5GHz != nil {
+		actions = append(actions, fmt.Sprintf("Switch to 5GHz band: %s is available on channel %d at %d dBm",
+			m.SSID, m.Band5GHz.Channel, m.Band5GHz.RSSI))
+	}
+	if m.SignalQuality == "weak" || (m.SNR != nil && *m.SNR < snrPoor) {
+		actions = append(actions, "Move closer to the access point or remove obstructions")
+	}
+	if m.CoChannelNetworks >= busyChannelAt {
+		actions = append(actions, fmt.Sprintf("Change the router's channel: %d other networks share channel %d",
+			m.CoChannelNetworks, m.Channel))
+	}
+
+	rec := Recommendation{Actions: actions, Metrics: m}
+	if len(actions) == 0 {
+		rec.Recommendation = "Connection looks healthy; no change needed"
+	} else {
+		rec.Recommendation = actions[0]
+	}
+	return rec
+}
+
+// portalCheckURL answers 204 No Content when the internet is reachable
+// directly; a captive portal intercepts it with a redirect or login page
+var portalCheckURL = "http://connectivitycheck.gstatic.com/generate_204"
+
+// Portal check statuses
+const (
+	portalOnline   = "online"
+	portalDetected = "captive_portal_detected"
+	portalOffline  = "offline"
+)
+
+// PortalStatus reports whether the current connection is behind a captive portal
+type PortalStatus struct {
+	SSID                  string `json:"ssid"`
+	Status                string `json:"status"`
+	CaptivePortalDetected bool   `json:"captive_portal_detected"`
+	CheckURL              string `json:"check_url"`
+	HTTPStatus            int    `json:"http_status,omitempty"`
+	PortalURL             string `json:"portal_url,omitempty"`
+	Error                 string `json:"error,omitempty"`
+}
+
+func newPortalCmd() *cobra.Command {
+	var checkURL string
+
+	cmd := &cobra.Command{
+		Use:   "portal",
+		Short: "Detect whether the current network requires a captive portal login",
+		Long: `Request a connectivity-check URL that normally answers 204 No Content.
+A redirect or any other response means a captive portal intercepted it;
+portal_url is the login page when the portal redirected. A request that
+fails outright reports status offline.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info, err := currentInfo()
+			if err != nil {
+				return err
+			}
+			if !info.Connected {
+				return output.PrintError("not_connected", "Not connected to a WiFi network", nil)
+			}
+
+			status := checkPortal(checkURL)
+			status.SSID = info.SSID
+			return output.Print(status)
+		},
+	}
+
+	cmd.Flags().StringVar(&checkURL, "check-url", portalCheckURL, "Connectivity-check URL expected to return 204")
+
+	return cmd
+}
+
+// checkPortal requests checkURL without following redirects and classifies
+// the response
+func checkPortal(checkURL string) PortalStatus {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	status := PortalStatus{CheckURL: checkURL}
+	resp, err := client.Get(checkURL)
+	if err != nil {
+		status.Status = portalOffline
+		status.Error = err.Error()
+		return status
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	status.HTTPStatus = resp.StatusCode
+	if resp.StatusCode == http.StatusNoContent {
+		status.Status = portalOnline
+		return status
+	}
+
+	status.Status = portalDetected
+	status.CaptivePortalDetected = true
+	if loc, err := resp.Location(); err == nil {
+		status.PortalURL = loc.String()
+	}
+	return status
+}
+
+// historyMaxBytes is the size at which the history log is rotated. One
+// previous generation is kept, so history uses at most twice this.
+const historyMaxBytes = 5 << 20
+
+// historyFile returns the location of the JSON-lines history log
+var historyFile = func() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "pocket", "wifi", "history.jsonl")
+}
+
+// HistorySample is one logged observation of the connection and nearby networks
+type HistorySample struct {
+	Timestamp string          `json:"timestamp"`
+	Current   *ConnectionInfo `json:"current,omitempty"`
+	Networks  []Network       `json:"networks,omitempty"`
+}
+
+func newHistoryCmd() *cobra.Command {
+	var logSample bool
+	var noScan bool
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Log WiFi samples over time and query them later",
+		Long: `With --log, record the current connection and a scan of nearby networks as
+one line in a JSON-lines log under the user cache directory. Run it
+periodically (e.g. from cron) to build a record of which networks and access
+points were seen, then query it with history show. The log rotates at 5 MB,
+keeping one previous file.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !logSample {
+				return cmd.Help()
+			}
+
+			info, err := currentInfo()
+			if err != nil {
+				return err
+			}
+			sample := HistorySample{
+				Timestamp: time.Now().UTC().Format(time.RFC3339),
+				Current:   &info,
+			}
+			if !noScan {
+				networks, err := scanNetworks(cacheEnabled())
+				if err != nil {
+					return err
+				}
+				sample.Networks = networks
+			}
+
+			if err := appendHistory(historyFile(), sample); err != nil {
+				return output.PrintError("write_failed", err.Error(), nil)
+			}
+			return output.Print(map[string]any{
+				"logged": sample,
+				"file":   historyFile(),
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&logSample, "log", false, "Append a sample of the current connection and nearby networks")
+	cmd.Flags().BoolVar(&noScan, "no-scan", false, "With --log, record only the current connection")
+
+	cmd.AddCommand(newHistoryShowCmd())
+
+	return cmd
+}
+
+func newHistoryShowCmd() *cobra.Command {
+	var since string
+	var ssid string
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show logged WiFi samples",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var cutoff time.Time
+			if since != "" {
+				c, err := parseSince(since, time.Now())
+				if err != nil {
+					return output.PrintError("invalid_input", err.Error(), nil)
+				}
+				cutoff = c
+			}
+
+			samples, err := readHistory(historyFile(), cutoff, ssid)
+			if err != nil {
+				return output.PrintError("read_failed", err.Error(), nil)
+			}
+			return output.Print(map[string]any{
+				"samples": samples,
+				"count":   len(samples),
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only samples after this: a duration (24h) or date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVar(&ssid, "ssid", "", "Only samples connected to or seeing this SSID")
+
+	return cmd
+}
+
+// parseSince resolves a --since value relative to now: a Go duration counts
+// back from now, otherwise it is a date or RFC 3339 timestamp
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a duration like 24h, YYYY-MM-DD or RFC3339", s)
+}
+
+// appendHistory writes sample as one JSON line to path, first rotating the
+// file to path.1 when it has reached historyMaxBytes
+func appendHistory(path string, sample HistorySample) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	if st, err := os.Stat(path); err == nil && st.Size() >= historyMaxBytes {
+		if err := os.Rename(path, path+".1"); err != nil {
+			return err
+		}
+	}
+
+	line, err := json.Marshal(sample)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readHistory returns the samples in the rotated and current logs, oldest
+// first, taken after cutoff (when set) and involving ssid (when set).
+// Malformed lines are skipped and missing files read as empty.
+func readHistory(path string, cutoff time.Time, ssid string) ([]HistorySample, error) {
+	samples := []HistorySample{}
+	for _, p := range []string{path + ".1", path} {
+		f, err := os.Open(p)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 4<<20)
+		for scanner.Scan() {
+			var s HistorySample
+			if json.Unmarshal(scanner.Bytes(), &s) != nil {
+				continue
+			}
+			if !cutoff.IsZero() {
+				ts, err := time.Parse(time.RFC3339, s.Timestamp)
+				if err != nil || !ts.After(cutoff) {
+					continue
+				}
+			}
+			if ssid != "" && !sampleInvolves(s, ssid) {
+				continue
+			}
+			samples = append(samples, s)
+		}
+		err = scanner.Err()
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return samples, nil
+}
+
+// sampleInvolves reports whether a sample was connected to or saw ssid
+func sampleInvolves(s HistorySample, ssid string) bool {
+	if s.Current != nil && s.Current.SSID == ssid {
+		return true
+	}
+	for _, n := range s.Networks {
+		if n.SSID == ssid {
+			return true
+		}
+	}
+	return false
+}
+
+// PowerState reports whether the WiFi radio is powered on
+type PowerState struct {
+	Interface string `json:"interface,omitempty"`
+	Enabled   bool   `json:"enabled"`
+}
+
+func newPowerCmd(use string, on bool) *cobra.Command {
+	var iface string
+
+	short := "Turn the WiFi radio on"
+	if !on {
+		short = "Turn the WiFi radio off"
+	}
+
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: short,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return currentConnection()
+			return setPower(iface, on)
 		},
 	}
+
+	cmd.Flags().StringVarP(&iface, "interface", "i", "en0", "WiFi interface (macOS only)")
+
+	return cmd
 }
 
-func scanNetworks() error {
+func setPower(iface string, on bool) error {
+	state := "off"
+	if on {
+		state = "on"
+	}
+
 	switch runtime.GOOS {
 	case "darwin":
-		return scanDarwin()
+		if out, err := exec.Command("networksetup", "-setairportpower", iface, state).CombinedOutput(); err != nil {
+			return output.PrintError("wifi_power_error",
+				fmt.Sprintf("networksetup failed: %v: %s", err, strings.TrimSpace(string(out))), nil)
+		}
+		out, err := exec.Command("networksetup", "-getairportpower", iface).CombinedOutput()
+		if err != nil {
+			return output.PrintError("wifi_power_error",
+				fmt.Sprintf("networksetup failed: %v", err), nil)
+		}
+		return output.Print(PowerState{Interface: iface, Enabled: parseAirportPower(string(out))})
 	case "linux":
-		return scanLinux()
+		if out, err := exec.Command("nmcli", "radio", "wifi", state).CombinedOutput(); err != nil {
+			return output.PrintError("wifi_power_error",
+				fmt.Sprintf("nmcli failed: %v: %s", err, strings.TrimSpace(string(out))),
+				map[string]string{"suggestion": "Changing radio state may require elevated privileges"})
+		}
+		out, err := exec.Command("nmcli", "radio", "wifi").CombinedOutput()
+		if err != nil {
+			return output.PrintError("wifi_power_error",
+				fmt.Sprintf("nmcli failed: %v", err), nil)
+		}
+		return output.Print(PowerState{Enabled: strings.TrimSpace(string(out)) == "enabled"})
 	default:
 		return output.PrintError("platform_unsupported",
+			fmt.Sprintf("WiFi power control not supported on %s", runtime.GOOS),
+			map[string]string{"supported": "macOS, Linux"})
+	}
+}
+
+// parseAirportPower parses "Wi-Fi Power (en0): On" from networksetup
+func parseAirportPower(out string) bool {
+	return strings.HasSuffix(strings.TrimSpace(out), ": On")
+}
+
+// scanNetworks scans nearby networks, reusing passive-cache output only when
+// useCache is set
+func scanNetworks(useCache bool) ([]Network, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return scanDarwin(useCache)
+	case "linux":
+		return scanLinux(useCache)
+	default:
+		return nil, output.PrintError("platform_unsupported",
 			fmt.Sprintf("WiFi scan not supported on %s", runtime.GOOS),
 			map[string]string{"supported": "macOS, Linux"})
 	}
 }
 
-func currentConnection() error {
+func currentConnection(all bool) error {
 	switch runtime.GOOS {
 	case "darwin":
-		return currentDarwin()
+		return currentDarwin(all)
 	case "linux":
-		return currentLinux()
+		return currentLinux(all)
 	default:
 		return output.PrintError("platform_unsupported",
 			fmt.Sprintf("WiFi info not supported on %s", runtime.GOOS),
@@ -101,6 +1178,24 @@ func currentConnection() error {
 	}
 }
 
+// currentInfo returns the current connection without printing it
+func currentInfo() (ConnectionInfo, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		out, err := darwinProfile()
+		if err != nil {
+			return ConnectionInfo{}, err
+		}
+		return parseSystemProfilerCurrent(out), nil
+	case "linux":
+		return currentInfoLinux()
+	default:
+		return ConnectionInfo{}, output.PrintError("platform_unsupported",
+			fmt.Sprintf("WiFi info not supported on %s", runtime.GOOS),
+			map[string]string{"supported": "macOS, Linux"})
+	}
+}
+
 // systemProfilerAirPort represents the JSON structure from system_profiler SPAirPortDataType -json
 type systemProfilerAirPort struct {
 	SPAirPortDataType []struct {
@@ -127,32 +1222,48 @@ type spAirPortNetwork struct {
 }
 
 // macOS implementation using system_profiler (airport CLI was removed in macOS 14 Sonoma)
-func scanDarwin() error {
-	out, err := exec.Command("system_profiler", "SPAirPortDataType", "-json").CombinedOutput()
+func scanDarwin(useCache bool) ([]Network, error) {
+	out, err := runScanner(useCache, cacheKey("system_profiler", "all"), "system_profiler", "SPAirPortDataType", "-json")
 	if err != nil {
-		return output.PrintError("wifi_scan_error",
+		return nil, output.PrintError("wifi_scan_error",
 			fmt.Sprintf("system_profiler failed: %v", err),
 			map[string]string{"suggestion": "WiFi may be disabled"})
 	}
 
-	networks := parseSystemProfilerScan(out)
+	return parseSystemProfilerScan(out), nil
+}
 
-	return output.Print(ScanResult{
-		Networks: networks,
-		Count:    len(networks),
-	})
+func currentDarwin(all bool) error {
+	out, err := darwinProfile()
+	if err != nil {
+		return err
+	}
+
+	if all {
+		return printInterfaces(parseSystemProfilerAll(out))
+	}
+
+	info := parseSystemProfilerCurrent(out)
+	return output.Print(info)
 }
 
-func currentDarwin() error {
-	out, err := exec.Command("system_profiler", "SPAirPortDataType", "-json").CombinedOutput()
+// darwinProfile returns the raw system_profiler WiFi JSON
+func darwinProfile() ([]byte, error) {
+	out, err := runCached(cacheKey("system_profiler", "all"), "system_profiler", "SPAirPortDataType", "-json")
 	if err != nil {
-		return output.PrintError("wifi_info_error",
+		return nil, output.PrintError("wifi_info_error",
 			fmt.Sprintf("system_profiler failed: %v", err),
 			map[string]string{"suggestion": "WiFi may be disabled"})
 	}
+	return out, nil
+}
 
-	info := parseSystemProfilerCurrent(out)
-	return output.Print(info)
+// printInterfaces outputs the connection state of several interfaces
+func printInterfaces(infos []ConnectionInfo) error {
+	return output.Print(map[string]any{
+		"count":      len(infos),
+		"interfaces": infos,
+	})
 }
 
 // parseSystemProfilerScan extracts nearby networks from system_profiler JSON output
@@ -165,9 +1276,11 @@ func parseSystemProfilerScan(data []byte) []Network {
 	var networks []Network
 	for _, net := range iface.OtherNetworks {
 		n := Network{
-			SSID:     net.Name,
-			Channel:  parseChannelNumber(net.Channel),
-			Security: cleanSecurityMode(net.SecurityMode),
+			SSID:         net.Name,
+			Channel:      parseChannelNumber(net.Channel),
+			Band:         parseChannelBand(net.Channel),
+			ChannelWidth: parseChannelWidth(net.Channel),
+			Security:     cleanSecurityMode(net.SecurityMode),
 		}
 		rssi, _ := parseSignalNoise(net.SignalNoise)
 		if rssi != 0 {
@@ -183,13 +1296,36 @@ func parseSystemProfilerScan(data []byte) []Network {
 
 // parseSystemProfilerCurrent extracts the current connection from system_profiler JSON output
 func parseSystemProfilerCurrent(data []byte) ConnectionInfo {
-	info := ConnectionInfo{}
-
 	iface := findWiFiInterface(data)
 	if iface == nil {
-		return info
+		return ConnectionInfo{}
 	}
 
+	return connectionFromInterface(iface)
+}
+
+// parseSystemProfilerAll extracts the connection state of every WiFi
+// interface in system_profiler JSON output
+func parseSystemProfilerAll(data []byte) []ConnectionInfo {
+	var sp systemProfilerAirPort
+	if err := json.Unmarshal(data, &sp); err != nil || len(sp.SPAirPortDataType) == 0 {
+		return []ConnectionInfo{}
+	}
+
+	ifaces := sp.SPAirPortDataType[0].Interfaces
+	infos := make([]ConnectionInfo, 0, len(ifaces))
+	for i := range ifaces {
+		info := connectionFromInterface(&ifaces[i])
+		info.Interface = ifaces[i].Name
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// connectionFromInterface builds connection details for a single interface
+func connectionFromInterface(iface *spAirPortInterface) ConnectionInfo {
+	info := ConnectionInfo{}
+
 	if iface.Status != "spairport_status_connected" || iface.CurrentNetwork == nil {
 		return info
 	}
@@ -198,6 +1334,7 @@ func parseSystemProfilerCurrent(data []byte) ConnectionInfo {
 	info.SSID = cur.Name
 	info.Connected = cur.Name != ""
 	info.Channel = parseChannelNumber(cur.Channel)
+	info.ChannelWidth = parseChannelWidth(cur.Channel)
 	info.Security = cleanSecurityMode(cur.SecurityMode)
 
 	rssi, noise := parseSignalNoise(cur.SignalNoise)
@@ -257,6 +1394,73 @@ func parseChannelNumber(ch string) int {
 	return v
 }
 
+// parseChannelBand extracts the frequency band from strings like "40 (5GHz, 80MHz)",
+// falling back to deriving it from the channel number when no band is given
+func parseChannelBand(ch string) string {
+	switch {
+	case strings.Contains(ch, "2GHz"):
+		return "2.4GHz"
+	case strings.Contains(ch, "5GHz"):
+		return "5GHz"
+	case strings.Contains(ch, "6GHz"):
+		return "6GHz"
+	}
+	return bandForChannel(parseChannelNumber(ch))
+}
+
+// parseChannelWidth extracts the channel width in MHz from strings like
+// "40 (5GHz, 80MHz)", returning 0 when no width is given
+func parseChannelWidth(ch string) int {
+	open := strings.Index(ch, "(")
+	if open < 0 {
+		return 0
+	}
+	for _, part := range strings.Split(strings.Trim(ch[open:], "()"), ",") {
+		part = strings.TrimSpace(part)
+		if !strings.HasSuffix(part, "MHz") {
+			continue
+		}
+		if v, err := strconv.Atoi(strings.TrimSuffix(part, "MHz")); err == nil {
+			return v
+		}
+	}
+	return 0
+}
+
+// bandForChannel derives the frequency band from a channel number.
+// Channel numbers overlap between 5GHz and 6GHz, so ambiguous channels
+// are reported as 5GHz, which is by far the more common case.
+func bandForChannel(ch int) string {
+	switch {
+	case ch >= 1 && ch <= 14:
+		return "2.4GHz"
+	case ch >= 32 && ch <= 177:
+		return "5GHz"
+	case ch > 177 && ch <= 233:
+		return "6GHz"
+	default:
+		return ""
+	}
+}
+
+// writeCSV writes networks as CSV rows with a header line
+func writeCSV(w io.Writer, networks []Network) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"ssid", "bssid", "rssi", "channel", "band", "security"})
+	for _, n := range networks {
+		_ = cw.Write([]string{
+			n.SSID,
+			n.BSSID,
+			strconv.Itoa(n.RSSI),
+			strconv.Itoa(n.Channel),
+			n.Band,
+			n.Security,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
 // cleanSecurityMode converts system_profiler security mode strings to human-readable form
 // e.g., "spairport_security_mode_wpa2_personal" -> "wpa2-personal"
 func cleanSecurityMode(mode string) string {
@@ -288,14 +1492,43 @@ func parseSignalNoise(sn string) (rssi int, noise int) {
 }
 
 // Linux implementation using nmcli
-func scanLinux() error {
-	out, err := exec.Command("nmcli", "-t", "-f", "SSID,BSSID,SIGNAL,CHAN,SECURITY", "dev", "wifi", "list").CombinedOutput()
+func scanLinux(useCache bool) ([]Network, error) {
+	out, err := runScanner(useCache, cacheKey("nmcli_scan", "all"), "nmcli", "-t", "-f", "SSID,BSSID,SIGNAL,CHAN,SECURITY", "dev", "wifi", "list")
 	if err != nil {
-		return output.PrintError("wifi_scan_error",
+		return nil, output.PrintError("wifi_scan_error",
 			fmt.Sprintf("nmcli scan failed: %v", err),
 			map[string]string{"suggestion": "Ensure NetworkManager is installed and WiFi is enabled"})
 	}
 
+	return parseNmcliScan(out), nil
+}
+
+// splitNmcliTerse splits a line of terse nmcli output on the colons that
+// separate fields. nmcli escapes colons and backslashes inside values (as in
+// BSSIDs: AA\:BB\:...), so those are unescaped rather than split on.
+func splitNmcliTerse(line string) []string {
+	var fields []string
+	var b strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+			b.WriteByte(line[i])
+		case line[i] == ':':
+			fields = append(fields, b.String())
+			b.Reset()
+		default:
+			b.WriteByte(line[i])
+		}
+	}
+	return append(fields, b.String())
+}
+
+// nmcliUnescaper undoes the escaping nmcli applies to terse values
+var nmcliUnescaper = strings.NewReplacer(`\:`, ":", `\\`, `\`)
+
+// parseNmcliScan parses terse nmcli output with SSID,BSSID,SIGNAL,CHAN,SECURITY fields
+func parseNmcliScan(out []byte) []Network {
 	var networks []Network
 	scanner := bufio.NewScanner(strings.NewReader(string(out)))
 	for scanner.Scan() {
@@ -303,8 +1536,8 @@ func scanLinux() error {
 		if line == "" {
 			continue
 		}
-		fields := strings.SplitN(line, ":", 5)
-		if len(fields) < 5 {
+		fields := splitNmcliTerse(line)
+		if len(fields) != 5 {
 			continue
 		}
 
@@ -319,27 +1552,82 @@ func scanLinux() error {
 		}
 		if ch, err := strconv.Atoi(fields[3]); err == nil {
 			n.Channel = ch
+			n.Band = bandForChannel(ch)
 		}
 		networks = append(networks, n)
 	}
 
-	return output.Print(ScanResult{
-		Networks: networks,
-		Count:    len(networks),
-	})
+	return networks
+}
+
+// nmcliShowFields are the device properties read for connection details
+const nmcliShowFields = "GENERAL.CONNECTION,WIFI.SSID,WIFI.BSSID,WIFI.CHAN,WIFI.RATE,WIFI.SIGNAL,WIFI.SECURITY"
+
+func currentLinux(all bool) error {
+	if all {
+		return currentLinuxAll()
+	}
+
+	info, err := currentInfoLinux()
+	if err != nil {
+		return err
+	}
+	return output.Print(info)
 }
 
-func currentLinux() error {
-	out, err := exec.Command("nmcli", "-t", "-f", "GENERAL.CONNECTION,WIFI.SSID,WIFI.BSSID,WIFI.CHAN,WIFI.RATE,WIFI.SIGNAL,WIFI.SECURITY", "dev", "show", "wlan0").CombinedOutput()
+// currentInfoLinux reads the connection details of the primary WiFi device
+func currentInfoLinux() (ConnectionInfo, error) {
+	out, err := runCached(cacheKey("nmcli_show", "wlan0"), "nmcli", "-t", "-f", nmcliShowFields, "dev", "show", "wlan0")
 	if err != nil {
 		// Try common alternative interface names
-		out, err = exec.Command("nmcli", "-t", "-f", "active,ssid,bssid,signal,chan,security", "dev", "wifi").CombinedOutput()
+		out, err = runCached(cacheKey("nmcli_wifi", "all"), "nmcli", "-t", "-f", "active,ssid,bssid,signal,chan,security", "dev", "wifi")
 		if err != nil {
-			return output.PrintError("wifi_info_error",
+			return ConnectionInfo{}, output.PrintError("wifi_info_error",
 				fmt.Sprintf("nmcli failed: %v", err), nil)
 		}
 	}
 
+	return parseNmcliDevShow(out), nil
+}
+
+// currentLinuxAll reports connection details for every WiFi device nmcli knows
+func currentLinuxAll() error {
+	out, err := runCached(cacheKey("nmcli_devices", "all"), "nmcli", "-t", "-f", "DEVICE,TYPE", "dev")
+	if err != nil {
+		return output.PrintError("wifi_info_error",
+			fmt.Sprintf("nmcli failed: %v", err), nil)
+	}
+
+	infos := []ConnectionInfo{}
+	for _, dev := range parseNmcliWiFiDevices(out) {
+		show, err := runCached(cacheKey("nmcli_show", dev), "nmcli", "-t", "-f", nmcliShowFields, "dev", "show", dev)
+		if err != nil {
+			return output.PrintError("wifi_info_error",
+				fmt.Sprintf("nmcli failed for %s: %v", dev, err), nil)
+		}
+		info := parseNmcliDevShow(show)
+		info.Interface = dev
+		infos = append(infos, info)
+	}
+
+	return printInterfaces(infos)
+}
+
+// parseNmcliWiFiDevices returns the wifi device names from terse DEVICE,TYPE output
+func parseNmcliWiFiDevices(out []byte) []string {
+	var devices []string
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 2)
+		if len(fields) == 2 && fields[1] == "wifi" {
+			devices = append(devices, fields[0])
+		}
+	}
+	return devices
+}
+
+// parseNmcliDevShow parses terse "nmcli dev show" KEY:value output
+func parseNmcliDevShow(out []byte) ConnectionInfo {
 	info := ConnectionInfo{}
 	scanner := bufio.NewScanner(strings.NewReader(string(out)))
 	for scanner.Scan() {
@@ -349,7 +1637,7 @@ func currentLinux() error {
 			continue
 		}
 		key := strings.TrimSpace(parts[0])
-		val := strings.TrimSpace(parts[1])
+		val := nmcliUnescaper.Replace(strings.TrimSpace(parts[1]))
 
 		switch key {
 		case "WIFI.SSID", "GENERAL.CONNECTION":
@@ -374,5 +1662,130 @@ func currentLinux() error {
 		}
 	}
 
-	return output.Print(info)
+	return info
+}
+
+// schemaTypes are the output types described by the schema command, keyed
+// by the name used to select one
+var schemaTypes = map[string]reflect.Type{
+	"scan":           reflect.TypeOf(ScanResult{}),
+	"network":        reflect.TypeOf(Network{}),
+	"current":        reflect.TypeOf(ConnectionInfo{}),
+	"stats":          reflect.TypeOf(NetworkStats{}),
+	"recommendation": reflect.TypeOf(Recommendation{}),
+	"speedtest":      reflect.TypeOf(SpeedtestReport{}),
+	"portal":         reflect.TypeOf(PortalStatus{}),
+	"history":        reflect.TypeOf(HistorySample{}),
+}
+
+// schemaTypeNames returns the keys of schemaTypes in sorted order
+func schemaTypeNames() []string {
+	names := make([]string, 0, len(schemaTypes))
+	for name := range schemaTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema [type]",
+		Short: "Print the JSON Schema of WiFi command output",
+		Long: `Print a JSON Schema generated from the output structs, so consumers can
+validate results and generate types. Pass a type for just that schema;
+otherwise all are printed. Types:
+  ` + strings.Join(schemaTypeNames(), ", "),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				t, ok := schemaTypes[args[0]]
+				if !ok {
+					return output.PrintError("invalid_input", "Unknown schema type: "+args[0],
+						map[string]any{"supported": schemaTypeNames()})
+				}
+				schema := jsonSchema(t)
+				schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+				schema["title"] = t.Name()
+				return output.Print(schema)
+			}
+
+			defs := make(map[string]any, len(schemaTypes))
+			for _, t := range schemaTypes {
+				defs[t.Name()] = jsonSchema(t)
+			}
+			return output.Print(map[string]any{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"$defs":   defs,
+			})
+		},
+	}
+
+	return cmd
+}
+
+// jsonSchema derives a JSON Schema for t from its Go type and json tags.
+// Fields tagged omitempty are optional; all others are required.
+func jsonSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return jsonSchema(t.Elem())
+	case reflect.Struct:
+		props := make(map[string]any)
+		required := []string{}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			prop := jsonSchema(f.Type)
+			if !strings.Contains(opts, "omitempty") {
+				switch f.Type.Kind() {
+				case reflect.Ptr, reflect.Slice, reflect.Map:
+					// Printed as null when nil, e.g. a scan that found nothing
+					prop = nullable(prop)
+				}
+				if f.Type.Kind() != reflect.Ptr {
+					required = append(required, name)
+				}
+			}
+			props[name] = prop
+		}
+		return map[string]any{
+			"type":                 "object",
+			"properties":           props,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": jsonSchema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{}
+	}
+}
+
+// nullable widens a schema's type to also allow null
+func nullable(schema map[string]any) map[string]any {
+	if t, ok := schema["type"].(string); ok {
+		schema["type"] = []string{t, "null"}
+	}
+	return schema
 }
@@ -1,7 +1,14 @@
 package wifi
 
 import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewCmd(t *testing.T) {
@@ -21,7 +28,7 @@ func TestNewCmd(t *testing.T) {
 		t.Error("expected alias 'wf'")
 	}
 
-	subs := map[string]bool{"scan": false, "current": false}
+	subs := map[string]bool{"scan": false, "current": false, "saved": false, "recommend": false, "portal": false, "history": false, "schema [type]": false, "enable": false, "disable": false}
 	for _, sub := range cmd.Commands() {
 		subs[sub.Use] = true
 	}
@@ -163,6 +170,9 @@ func TestParseSystemProfilerScan(t *testing.T) {
 	if networks[2].Channel != 36 {
 		t.Errorf("network 2 Channel = %d, want 36", networks[2].Channel)
 	}
+	if networks[2].ChannelWidth != 160 {
+		t.Errorf("network 2 ChannelWidth = %d, want 160", networks[2].ChannelWidth)
+	}
 }
 
 func TestParseSystemProfilerScanEmpty(t *testing.T) {
@@ -236,6 +246,9 @@ func TestParseSystemProfilerCurrent(t *testing.T) {
 	if info.Channel != 149 {
 		t.Errorf("Channel = %d, want 149", info.Channel)
 	}
+	if info.ChannelWidth != 80 {
+		t.Errorf("ChannelWidth = %d, want 80", info.ChannelWidth)
+	}
 	if info.TxRate != "866 Mbps" {
 		t.Errorf("TxRate = %q, want '866 Mbps'", info.TxRate)
 	}
@@ -358,3 +371,541 @@ func TestScanResultTypes(t *testing.T) {
 		t.Errorf("expected 2 networks, got %d", len(result.Networks))
 	}
 }
+
+func TestParseChannelBand(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"11 (2GHz, 20MHz)", "2.4GHz"},
+		{"40 (5GHz, 80MHz)", "5GHz"},
+		{"37 (6GHz, 160MHz)", "6GHz"},
+		{"6", "2.4GHz"},
+		{"149", "5GHz"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		got := parseChannelBand(tt.input)
+		if got != tt.want {
+			t.Errorf("parseChannelBand(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseChannelWidth(t *testing.T) {
+	tests := []struct {
+		input string
+		want  int
+	}{
+		{"11 (2GHz, 20MHz)", 20},
+		{"1 (2GHz, 40MHz)", 40},
+		{"149 (5GHz, 80MHz)", 80},
+		{"36 (5GHz, 160MHz)", 160},
+		{"6", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		got := parseChannelWidth(tt.input)
+		if got != tt.want {
+			t.Errorf("parseChannelWidth(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseNmcliScan(t *testing.T) {
+	input := []byte("Home:AA\\:BB\\:CC\\:DD\\:EE\\:01:80:6:WPA2\n" +
+		"Office:AA\\:BB\\:CC\\:DD\\:EE\\:02:60:36:WPA3\n" +
+		"Lab\\:2:AA\\:BB\\:CC\\:DD\\:EE\\:03:40:11:\n\n")
+	networks := parseNmcliScan(input)
+	if len(networks) != 3 {
+		t.Fatalf("expected 3 networks, got %d", len(networks))
+	}
+	if networks[0].BSSID != "AA:BB:CC:DD:EE:01" || networks[0].RSSI != -20 || networks[0].Security != "WPA2" {
+		t.Errorf("unexpected first network: %+v", networks[0])
+	}
+	if networks[2].SSID != "Lab:2" || networks[2].Channel != 11 || networks[2].Security != "" {
+		t.Errorf("escaped SSID parsed as %+v", networks[2])
+	}
+	if networks[1].Channel != 36 || networks[1].Band != "5GHz" {
+		t.Errorf("unexpected channel/band: %d %q", networks[1].Channel, networks[1].Band)
+	}
+	if networks[1].RSSI != -40 {
+		t.Errorf("expected RSSI -40, got %d", networks[1].RSSI)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeCSV(&buf, []Network{
+		{SSID: "Cafe, Upstairs", BSSID: "aa:bb", RSSI: -60, Channel: 6, Band: "2.4GHz", Security: "open"},
+	})
+	if err != nil {
+		t.Fatalf("writeCSV failed: %v", err)
+	}
+
+	want := "ssid,bssid,rssi,channel,band,security\n\"Cafe, Upstairs\",aa:bb,-60,6,2.4GHz,open\n"
+	if buf.String() != want {
+		t.Errorf("writeCSV output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteStatsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeStatsCSV(&buf, []NetworkStats{
+		{SSID: "Cafe, Upstairs", BSSID: "aa:bb", AvgRSSI: -62.5, MinRSSI: -70, MaxRSSI: -55, SeenCount: 4},
+	})
+	if err != nil {
+		t.Fatalf("writeStatsCSV failed: %v", err)
+	}
+
+	want := "ssid,bssid,avg_rssi,min_rssi,max_rssi,seen_count\n\"Cafe, Upstairs\",aa:bb,-62.5,-70,-55,4\n"
+	if buf.String() != want {
+		t.Errorf("writeStatsCSV output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	oldCacheFile := cacheFile
+	cacheFile = func(key string) string { return filepath.Join(dir, key+".json") }
+	defer func() { cacheFile = oldCacheFile }()
+
+	if _, ok := readCache("test", time.Minute); ok {
+		t.Fatal("expected cache miss before write")
+	}
+
+	writeCache("test", []byte("raw output"))
+
+	out, ok := readCache("test", time.Minute)
+	if !ok || string(out) != "raw output" {
+		t.Errorf("readCache = %q, %v; want %q, true", out, ok, "raw output")
+	}
+
+	if _, ok := readCache("test", 0); ok {
+		t.Error("expected expired entry to miss")
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	tests := []struct {
+		command, iface, want string
+	}{
+		{"nmcli_show", "wlan0", "nmcli_show_wlan0"},
+		{"nmcli_show", "wlp2s0", "nmcli_show_wlp2s0"},
+		{"system_profiler", "", "system_profiler_all"},
+		{"nmcli_show", "../x", "nmcli_show_.._x"},
+	}
+	for _, tt := range tests {
+		if got := cacheKey(tt.command, tt.iface); got != tt.want {
+			t.Errorf("cacheKey(%q, %q) = %q, want %q", tt.command, tt.iface, got, tt.want)
+		}
+	}
+}
+
+func TestParseAirportPower(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"Wi-Fi Power (en0): On\n", true},
+		{"Wi-Fi Power (en0): Off\n", false},
+		{"en1 is not a Wi-Fi interface.", false},
+	}
+	for _, tt := range tests {
+		if got := parseAirportPower(tt.input); got != tt.want {
+			t.Errorf("parseAirportPower(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseSystemProfilerAll(t *testing.T) {
+	input := []byte(`{
+  "SPAirPortDataType": [
+    {
+      "spairport_airport_interfaces": [
+        {
+          "_name": "en0",
+          "spairport_status_information": "spairport_status_connected",
+          "spairport_current_network_information": {
+            "_name": "Kiosk",
+            "spairport_network_channel": "36 (5GHz, 80MHz)",
+            "spairport_signal_noise": "-50 dBm / -92 dBm"
+          }
+        },
+        {
+          "_name": "en1",
+          "spairport_status_information": "spairport_status_off"
+        }
+      ]
+    }
+  ]
+}`)
+
+	infos := parseSystemProfilerAll(input)
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 interfaces, got %d", len(infos))
+	}
+	if infos[0].Interface != "en0" || !infos[0].Connected || infos[0].SSID != "Kiosk" {
+		t.Errorf("unexpected en0 info: %+v", infos[0])
+	}
+	if infos[1].Interface != "en1" || infos[1].Connected {
+		t.Errorf("unexpected en1 info: %+v", infos[1])
+	}
+
+	if got := parseSystemProfilerAll([]byte(`not json`)); len(got) != 0 {
+		t.Errorf("expected no interfaces for invalid JSON, got %d", len(got))
+	}
+}
+
+func TestParseNmcliWiFiDevices(t *testing.T) {
+	out := []byte("wlan0:wifi\neth0:ethernet\nwlan1:wifi\nlo:loopback\n")
+	got := parseNmcliWiFiDevices(out)
+	if len(got) != 2 || got[0] != "wlan0" || got[1] != "wlan1" {
+		t.Errorf("parseNmcliWiFiDevices = %v, want [wlan0 wlan1]", got)
+	}
+}
+
+func TestParseNmcliDevShow(t *testing.T) {
+	out := []byte("GENERAL.CONNECTION:Home\nWIFI.BSSID:AA\\:BB\\:CC\\:DD\\:EE\\:FF\nWIFI.CHAN:6\nWIFI.SIGNAL:70\n")
+	info := parseNmcliDevShow(out)
+	if !info.Connected || info.SSID != "Home" {
+		t.Errorf("unexpected connection: %+v", info)
+	}
+	if info.Channel != 6 || info.RSSI != -30 {
+		t.Errorf("unexpected channel/rssi: %d %d", info.Channel, info.RSSI)
+	}
+	if info.BSSID != "AA:BB:CC:DD:EE:FF" {
+		t.Errorf("BSSID = %q, want unescaped AA:BB:CC:DD:EE:FF", info.BSSID)
+	}
+}
+
+func TestCompareToCurrentLinux(t *testing.T) {
+	cur := parseNmcliDevShow([]byte("GENERAL.CONNECTION:Office\n" +
+		"WIFI.SSID:Office\nWIFI.BSSID:AA\\:BB\\:CC\\:DD\\:EE\\:01\nWIFI.CHAN:6\nWIFI.SIGNAL:40\n"))
+	networks := parseNmcliScan([]byte("Office:AA\\:BB\\:CC\\:DD\\:EE\\:01:40:6:WPA2\n" +
+		"Office:AA\\:BB\\:CC\\:DD\\:EE\\:02:75:36:WPA2\n"))
+
+	s := compareToCurrent(cur, networks)
+	if !networks[0].Connected {
+		t.Errorf("connected AP not marked: %+v (current BSSID %q)", networks[0], cur.BSSID)
+	}
+	if s == nil || s.BSSID != "AA:BB:CC:DD:EE:02" {
+		t.Errorf("suggestion = %+v, want AA:BB:CC:DD:EE:02", s)
+	}
+}
+
+func TestAggregateScans(t *testing.T) {
+	scans := [][]Network{
+		{{SSID: "Home", BSSID: "aa", RSSI: -60}, {SSID: "Cafe", RSSI: -80}},
+		{{SSID: "Home", BSSID: "aa", RSSI: -50}},
+		{{SSID: "Home", BSSID: "aa", RSSI: -70}, {SSID: "Cafe", RSSI: -70}, {SSID: "Home", BSSID: "bb", RSSI: -40}},
+	}
+
+	stats := aggregateScans(scans)
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 networks, got %d", len(stats))
+	}
+
+	// Sorted strongest average first
+	if stats[0].BSSID != "bb" || stats[0].SeenCount != 1 {
+		t.Errorf("unexpected first entry: %+v", stats[0])
+	}
+	home := stats[1]
+	if home.BSSID != "aa" || home.AvgRSSI != -60 || home.MinRSSI != -70 || home.MaxRSSI != -50 || home.SeenCount != 3 {
+		t.Errorf("unexpected Home/aa stats: %+v", home)
+	}
+	cafe := stats[2]
+	if cafe.SSID != "Cafe" || cafe.AvgRSSI != -75 || cafe.SeenCount != 2 {
+		t.Errorf("unexpected Cafe stats: %+v", cafe)
+	}
+}
+
+func TestParsePreferredNetworks(t *testing.T) {
+	out := "Preferred networks on en0:\n\tHome\n\tOffice 5G\n"
+	got := parsePreferredNetworks(out)
+	if len(got) != 2 || got[0] != "Home" || got[1] != "Office 5G" {
+		t.Errorf("parsePreferredNetworks = %v", got)
+	}
+}
+
+func TestParseNmcliSavedConnections(t *testing.T) {
+	out := "Home:802-11-wireless\nWired connection 1:802-3-ethernet\nCafe\\:Guest:802-11-wireless\n"
+	got := parseNmcliSavedConnections(out)
+	if len(got) != 2 || got[0] != "Home" || got[1] != "Cafe:Guest" {
+		t.Errorf("parseNmcliSavedConnections = %v", got)
+	}
+}
+
+func TestFilterKnown(t *testing.T) {
+	networks := []Network{{SSID: "Home"}, {SSID: "Stranger"}, {SSID: "Office"}}
+	got := filterKnown(networks, []string{"Office", "Home", "Elsewhere"})
+	if len(got) != 2 || got[0].SSID != "Home" || got[1].SSID != "Office" {
+		t.Errorf("filterKnown = %+v", got)
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	tests := []struct {
+		name     string
+		cur      ConnectionInfo
+		networks []Network
+		want     string
+	}{
+		{
+			name: "healthy",
+			cur:  ConnectionInfo{SSID: "Home", RSSI: -50, Noise: -90, Channel: 36, Connected: true},
+			networks: []Network{
+				{SSID: "Home", RSSI: -50, Channel: 36, Band: "5GHz"},
+			},
+			want: "Connection looks healthy; no change needed",
+		},
+		{
+			name: "stronger AP",
+			cur:  ConnectionInfo{SSID: "Office", RSSI: -72, Noise: -92, Channel: 1, Connected: true},
+			networks: []Network{
+				{SSID: "Office", RSSI: -72, Channel: 1, Band: "2.4GHz"},
+				{SSID: "Office", RSSI: -55, Channel: 6, Band: "2.4GHz"},
+			},
+			want: "Roam to stronger AP: Office on channel 6 is 17 dB stronger",
+		},
+		{
+			name: "5GHz available",
+			cur:  ConnectionInfo{SSID: "Home", RSSI: -58, Noise: -90, Channel: 6, Connected: true},
+			networks: []Network{
+				{SSID: "Home", RSSI: -62, Channel: 44, Band: "5GHz"},
+			},
+			want: "Switch to 5GHz band: Home is available on channel 44 at -62 dBm",
+		},
+		{
+			name: "weak signal",
+			cur:  ConnectionInfo{SSID: "Cafe", RSSI: -78, Noise: -88, Channel: 11, Connected: true},
+			want: "Move closer to the access point or remove obstructions",
+		},
+		{
+			name: "busy channel",
+			cur:  ConnectionInfo{SSID: "Home", RSSI: -50, Channel: 6, Connected: true},
+			networks: []Network{
+				{SSID: "A", Channel: 6}, {SSID: "B", Channel: 6}, {SSID: "C", Channel: 6},
+			},
+			want: "Change the router's channel: 3 other networks share channel 6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := recommend(tt.cur, tt.networks)
+			if rec.Recommendation != tt.want {
+				t.Errorf("Recommendation = %q, want %q", rec.Recommendation, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareToCurrent(t *testing.T) {
+	cur := ConnectionInfo{SSID: "Office", BSSID: "aa:aa", RSSI: -72, Channel: 1, Connected: true}
+	networks := []Network{
+		{SSID: "Office", BSSID: "aa:aa", RSSI: -71, Channel: 1},
+		{SSID: "Office", BSSID: "bb:bb", RSSI: -68, Channel: 6},
+		{SSID: "Office", BSSID: "cc:cc", RSSI: -55, Channel: 36, Band: "5GHz"},
+		{SSID: "Office", BSSID: "dd:dd", RSSI: -60, Channel: 11},
+		{SSID: "Guest", BSSID: "ee:ee", RSSI: -40, Channel: 6},
+	}
+
+	s := compareToCurrent(cur, networks)
+	if s == nil {
+		t.Fatal("expected a roam suggestion")
+	}
+	if s.BSSID != "cc:cc" || s.GainDB != 17 || s.CurrentRSSI != -72 {
+		t.Errorf("suggestion = %+v, want cc:cc with 17 dB gain", s)
+	}
+
+	if !networks[0].Connected || networks[0].RSSIDelta != nil {
+		t.Errorf("connected AP annotated as %+v", networks[0])
+	}
+	if networks[1].RoamCandidate || networks[1].RSSIDelta == nil || *networks[1].RSSIDelta != 4 {
+		t.Errorf("weaker same-SSID AP annotated as %+v", networks[1])
+	}
+	if !networks[2].RoamCandidate || !networks[3].RoamCandidate {
+		t.Error("expected APs 8+ dB stronger to be roam candidates")
+	}
+	if networks[4].Connected || networks[4].RSSIDelta != nil || networks[4].RoamCandidate {
+		t.Errorf("other SSID annotated as %+v", networks[4])
+	}
+
+	if s := compareToCurrent(cur, networks[:2]); s != nil {
+		t.Errorf("suggestion = %+v, want none without a meaningfully stronger AP", s)
+	}
+}
+
+func TestJSONSchema(t *testing.T) {
+	schema := jsonSchema(reflect.TypeOf(ScanResult{}))
+	if schema["type"] != "object" {
+		t.Fatalf("type = %v, want object", schema["type"])
+	}
+
+	props := schema["properties"].(map[string]any)
+	// A scan that finds nothing prints "networks": null
+	networks := props["networks"].(map[string]any)
+	if typ, _ := networks["type"].([]string); len(typ) != 2 || typ[0] != "array" || typ[1] != "null" {
+		t.Errorf("networks type = %v, want [array null]", networks["type"])
+	}
+
+	item := networks["items"].(map[string]any)
+	itemProps := item["properties"].(map[string]any)
+	if rssi := itemProps["rssi"].(map[string]any); rssi["type"] != "integer" {
+		t.Errorf("rssi type = %v, want integer", rssi["type"])
+	}
+	if _, ok := itemProps["channel_width_mhz"]; !ok {
+		t.Error("expected channel_width_mhz from the json tag")
+	}
+	// Only ssid lacks omitempty on Network
+	if req := item["required"].([]string); len(req) != 1 || req[0] != "ssid" {
+		t.Errorf("required = %v, want [ssid]", req)
+	}
+}
+
+func TestMeasureThroughput(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 64*1024)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(payload)
+	}))
+	defer srv.Close()
+
+	tp, err := measureThroughput(srv.URL)
+	if err != nil {
+		t.Fatalf("measureThroughput: %v", err)
+	}
+	if tp.Bytes != int64(len(payload)) {
+		t.Errorf("bytes = %d, want %d", tp.Bytes, len(payload))
+	}
+	if tp.Mbps <= 0 {
+		t.Errorf("mbps = %v, want > 0", tp.Mbps)
+	}
+
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+	if _, err := measureThroughput(missing.URL); err == nil {
+		t.Error("expected error for HTTP 404")
+	}
+}
+
+func TestLinkRateMbps(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{"866 Mbps", 866},
+		{"433.3 Mbit/s", 433.3},
+		{"", 0},
+		{"unknown", 0},
+	}
+	for _, tt := range tests {
+		if got := linkRateMbps(tt.in); got != tt.want {
+			t.Errorf("linkRateMbps(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFilterOpen(t *testing.T) {
+	networks := []Network{
+		{SSID: "Cafe", Security: "open"},
+		{SSID: "Home", Security: "wpa2-personal"},
+		{SSID: "Airport", Security: "--"},
+		{SSID: "Lobby", Security: "none"},
+	}
+	got := filterOpen(networks)
+	if len(got) != 3 || got[0].SSID != "Cafe" || got[1].SSID != "Airport" || got[2].SSID != "Lobby" {
+		t.Errorf("filterOpen = %+v", got)
+	}
+}
+
+func TestCheckPortal(t *testing.T) {
+	online := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer online.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://login.example/portal", http.StatusFound)
+	}))
+	defer redirect.Close()
+	page := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<html>Accept terms</html>"))
+	}))
+	defer page.Close()
+
+	tests := []struct {
+		url        string
+		wantStatus string
+		wantPortal string
+	}{
+		{online.URL, portalOnline, ""},
+		{redirect.URL, portalDetected, "http://login.example/portal"},
+		{page.URL, portalDetected, ""},
+		{"http://127.0.0.1:1", portalOffline, ""},
+	}
+	for _, tt := range tests {
+		got := checkPortal(tt.url)
+		if got.Status != tt.wantStatus || got.PortalURL != tt.wantPortal {
+			t.Errorf("checkPortal(%s) = %+v, want status %s portal %q", tt.url, got, tt.wantStatus, tt.wantPortal)
+		}
+		if got.CaptivePortalDetected != (tt.wantStatus == portalDetected) {
+			t.Errorf("checkPortal(%s) detected = %v", tt.url, got.CaptivePortalDetected)
+		}
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	samples := []HistorySample{
+		{Timestamp: now.Add(-48 * time.Hour).Format(time.RFC3339), Current: &ConnectionInfo{SSID: "Home"}},
+		{Timestamp: now.Add(-time.Hour).Format(time.RFC3339), Networks: []Network{{SSID: "Cafe"}}},
+		{Timestamp: now.Format(time.RFC3339), Current: &ConnectionInfo{SSID: "Office"}},
+	}
+	for _, s := range samples {
+		if err := appendHistory(path, s); err != nil {
+			t.Fatalf("appendHistory: %v", err)
+		}
+	}
+
+	all, err := readHistory(path, time.Time{}, "")
+	if err != nil || len(all) != 3 {
+		t.Fatalf("readHistory = %d samples, %v; want 3", len(all), err)
+	}
+
+	cutoff, err := parseSince("24h", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recent, _ := readHistory(path, cutoff, "")
+	if len(recent) != 2 {
+		t.Errorf("since 24h = %d samples, want 2", len(recent))
+	}
+
+	cafe, _ := readHistory(path, time.Time{}, "Cafe")
+	if len(cafe) != 1 || cafe[0].Networks[0].SSID != "Cafe" {
+		t.Errorf("ssid filter = %+v", cafe)
+	}
+
+	if _, err := parseSince("yesterday", now); err == nil {
+		t.Error("expected error for invalid --since")
+	}
+}
+
+func TestHistoryRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	if err := os.WriteFile(path, bytes.Repeat([]byte("x"), historyMaxBytes), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := appendHistory(path, HistorySample{Timestamp: "2024-06-01T00:00:00Z"}); err != nil {
+		t.Fatalf("appendHistory: %v", err)
+	}
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Errorf("expected rotated file: %v", err)
+	}
+	if st, _ := os.Stat(path); st == nil || st.Size() >= historyMaxBytes {
+		t.Error("expected a fresh history file after rotation")
+	}
+}
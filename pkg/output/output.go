@@ -39,6 +39,12 @@ func SetFormat(f string) {
 	format = f
 }
 
+// Format returns the global output format, for commands that serialize
+// specially for some formats (e.g. contacts get -o vcard)
+func Format() string {
+	return format
+}
+
 // SetVerbose sets verbose mode
 func SetVerbose(v bool) {
 	verbose = v
@@ -163,6 +163,9 @@ func TestSetFormat(t *testing.T) {
 	if format != "text" {
 		t.Errorf("expected format=text, got %s", format)
 	}
+	if Format() != "text" {
+		t.Errorf("expected Format()=text, got %s", Format())
+	}
 
 	SetFormat("table")
 	if format != "table" {